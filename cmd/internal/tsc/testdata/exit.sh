@@ -0,0 +1 @@
+{{if eq .Name "fail"}}exit 1{{end}}
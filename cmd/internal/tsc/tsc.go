@@ -16,6 +16,8 @@ import (
 	"time"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/rjeczalik/gh/webhook"
 )
 
 func nonil(err ...error) error {
@@ -72,7 +74,10 @@ func New(file string, args []string) (*Script, error) {
 	return s, nil
 }
 
-func (s *Script) Webhook(event string, payload interface{}) {
+// Webhook executes the script for the event. A non-nil error is returned,
+// if the template fails to execute or the bash script exits with
+// a non-zero status.
+func (s *Script) Webhook(event string, payload interface{}) error {
 	e := &Event{
 		Name:    event,
 		Payload: payload,
@@ -85,8 +90,9 @@ func (s *Script) Webhook(event string, payload interface{}) {
 		err = s.execute(s.output(), e)
 	}
 	if err != nil {
-		s.logf("ERROR template script error: %v", err)
+		return fmt.Errorf("template script error: %v", err)
 	}
+	return nil
 }
 
 func (s *Script) runBash(e *Event) (err error) {
@@ -158,6 +164,35 @@ func (s *Script) funcs() template.FuncMap {
 			}
 			return buf.String(), nil
 		},
+		"branch": func(payload interface{}) string {
+			if e, ok := payload.(webhook.RefEvent); ok {
+				return e.Branch()
+			}
+			return ""
+		},
+		"tag": func(payload interface{}) string {
+			if e, ok := payload.(webhook.RefEvent); ok {
+				return e.Tag()
+			}
+			return ""
+		},
+		"isTag": func(payload interface{}) bool {
+			e, ok := payload.(webhook.RefEvent)
+			return ok && e.IsTag()
+		},
+		"isBranchCreation": func(payload interface{}) bool {
+			e, ok := payload.(webhook.RefEvent)
+			return ok && e.IsBranchCreation()
+		},
+		"isBranchDeletion": func(payload interface{}) bool {
+			e, ok := payload.(webhook.RefEvent)
+			return ok && e.IsBranchDeletion()
+		},
+		"isDefaultBranch": func(payload interface{}) bool {
+			e, ok := payload.(webhook.RefEvent)
+			return ok && e.IsDefaultBranch()
+		},
+		"shortSHA": webhook.ShortSHA,
 		"logf": func(format string, v ...interface{}) string {
 			if format == "" {
 				return ""
@@ -5,6 +5,8 @@ import (
 	"io"
 	"path/filepath"
 	"testing"
+
+	"github.com/rjeczalik/gh/webhook"
 )
 
 var script = filepath.Join("testdata", "script.tsc")
@@ -45,10 +47,56 @@ func TestScript(t *testing.T) {
 		}
 		buf, out := pipe()
 		sc.OutputFunc = out
-		sc.Webhook(cas.name, cas.payload)
+		if err := sc.Webhook(cas.name, cas.payload); err != nil {
+			t.Errorf("Webhook()=%v (i=%d)", err, i)
+			continue
+		}
 		if !bytes.Equal(buf.Bytes(), cas.output) {
 			t.Errorf("want output=%q; got %q (i=%d)", cas.output, buf.Bytes(), i)
 			continue
 		}
 	}
 }
+
+func TestScriptRefFuncs(t *testing.T) {
+	cases := [...]struct {
+		payload interface{}
+		output  string
+	}{{
+		&webhook.PushEvent{Ref: "refs/heads/master", Created: true, Repository: webhook.Repository{DefaultBranch: "master"}},
+		"branch=master tag= isTag=false default=true created=true deleted=false short=0d1a26e\n",
+	}, {
+		&webhook.DeleteEvent{Ref: "v1.0.0", RefType: "tag"},
+		"branch= tag=v1.0.0 isTag=true default=false created=false deleted=false short=0d1a26e\n",
+	}, {
+		"payload",
+		"branch= tag= isTag=false default=false created=false deleted=false short=0d1a26e\n",
+	}}
+	sc, err := New(filepath.Join("testdata", "ref.tsc"), nil)
+	if err != nil {
+		t.Fatalf("New()=%v", err)
+	}
+	for i, cas := range cases {
+		buf, out := pipe()
+		sc.OutputFunc = out
+		if err := sc.Webhook("push", cas.payload); err != nil {
+			t.Errorf("Webhook()=%v (i=%d)", err, i)
+		}
+		if buf.String() != cas.output {
+			t.Errorf("want output=%q; got %q (i=%d)", cas.output, buf.String(), i)
+		}
+	}
+}
+
+func TestScriptError(t *testing.T) {
+	sc, err := New(filepath.Join("testdata", "exit.sh"), nil)
+	if err != nil {
+		t.Fatalf("New()=%v", err)
+	}
+	if err := sc.Webhook("push", nil); err != nil {
+		t.Errorf("want Webhook to succeed; got %v", err)
+	}
+	if err := sc.Webhook("fail", nil); err == nil {
+		t.Error("want Webhook to fail for non-zero exit status")
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"text/template"
 	"time"
 	"unicode"
+
+	"github.com/rjeczalik/gh/webhook"
 )
 
 var dir = flag.String("dir", "", "directory with json files")
@@ -104,6 +106,10 @@ func (ms *memberSet) Add(m member) {
 				(*ms)[i].Typ = "Time"
 				break
 			}
+			if isNumber(typ) && isNumber(m.Typ) {
+				(*ms)[i].Typ = "json.Number"
+				break
+			}
 			(*ms)[i].Typ = "interface{}"
 			fmt.Fprintf(os.Stderr, "different types for %s member: %s and %s, using interface{}\n", m.Name, typ, m.Typ)
 		}
@@ -163,7 +169,9 @@ func (t typeTree) push(e rawEvent) {
 		typ, v map[string]interface{}
 	}
 	var v map[string]interface{}
-	if err := json.Unmarshal([]byte(e.PayloadJSON), &v); err != nil {
+	dec := json.NewDecoder(strings.NewReader(e.PayloadJSON))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
 		die(err)
 	}
 	typ, ok := t.m[e.Name].(map[string]interface{})
@@ -184,6 +192,10 @@ func (t typeTree) push(e rawEvent) {
 			case rtyp == nil && rv != nil && rv.Kind() == reflect.Map:
 				typ = make(map[string]interface{})
 				nd.typ[k] = typ
+			case rtyp == numberType && rv == numberType:
+				if typ != ambiguous && isInt(typ.(json.Number)) != isInt(v.(json.Number)) {
+					nd.typ[k] = ambiguous
+				}
 			case rtyp != nil && rv != nil && rtyp != rv:
 				die(fmt.Sprintf("merge: incompatible types for %s: %T vs %v", k, v, typ))
 			default:
@@ -195,6 +207,61 @@ func (t typeTree) push(e rawEvent) {
 	}
 }
 
+var numberType = reflect.TypeOf(json.Number(""))
+
+// ambiguous marks a number member, which is an integer in some payloads and
+// a floating point value in the others.
+const ambiguous = json.Number("")
+
+func isInt(n json.Number) bool {
+	_, err := n.Int64()
+	return err == nil
+}
+
+// counts lists members, which are counters despite the lack of Count suffix.
+var counts = map[string]bool{
+	"Additions":      true,
+	"ChangedFiles":   true,
+	"Comments":       true,
+	"Commits":        true,
+	"Deletions":      true,
+	"Followers":      true,
+	"Following":      true,
+	"Forks":          true,
+	"OpenIssues":     true,
+	"PublicGists":    true,
+	"PublicRepos":    true,
+	"ReviewComments": true,
+	"Size":           true,
+	"Stargazers":     true,
+	"Total":          true,
+	"Watchers":       true,
+}
+
+// intType gives int64 type for identifiers and counters, and int for the rest
+// of the integers.
+func intType(name string) string {
+	if strings.HasSuffix(name, "ID") || strings.HasSuffix(name, "Count") || counts[name] {
+		return "int64"
+	}
+	return "int"
+}
+
+func isNumber(typ string) bool {
+	return typ == "int" || typ == "int64" || typ == "float64" || typ == "json.Number"
+}
+
+func usesNumber(obj []object) bool {
+	for _, o := range obj {
+		for _, m := range o.Members {
+			if strings.HasSuffix(m.Typ, "json.Number") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (t typeTree) objects() (obj []object) {
 	var stack = make([]node, 0, len(t.m))
 	for k, v := range t.m {
@@ -234,7 +301,9 @@ const header = `// Created by go generate; DO NOT EDIT
 
 package {{pkg}}
 
-`
+{{if .}}import "encoding/json"
+
+{{end}}`
 
 const types = `{{range $_, $o := .}}// {{$o.Name}} was autogenerated by go generate.
 type {{$o.Name}} struct {
@@ -348,8 +417,15 @@ func (t typeTree) setType(m *member, v interface{}, parent string, stack *[]node
 			}
 		case bool:
 			m.Typ = "bool"
-		case float64:
-			m.Typ = "float64"
+		case json.Number:
+			switch {
+			case v == ambiguous:
+				m.Typ = "json.Number"
+			case isInt(v):
+				m.Typ = intType(m.Name)
+			default:
+				m.Typ = "float64"
+			}
 		case []interface{}:
 			if len(v) == 0 {
 				m.Typ = "[]string"
@@ -381,7 +457,7 @@ func (t typeTree) setType(m *member, v interface{}, parent string, stack *[]node
 
 func readData(dir string, fis ...os.FileInfo) (events []rawEvent) {
 	for _, fi := range fis {
-		event := strings.ToLower(fi.Name())
+		event := strings.TrimSuffix(strings.ToLower(fi.Name()), ".gz")
 		if !strings.HasSuffix(event, ".json") {
 			log.Println("webhook: ignoring", fi.Name())
 			continue
@@ -400,7 +476,11 @@ func readData(dir string, fis ...os.FileInfo) (events []rawEvent) {
 		if err != nil {
 			die(err)
 		}
-		events = append(events, rawEvent{Name: event, PayloadJSON: string(body)})
+		payload, err := webhook.ReadPayload(body)
+		if err != nil {
+			die(err)
+		}
+		events = append(events, rawEvent{Name: event, PayloadJSON: string(payload)})
 	}
 	return events
 }
@@ -453,12 +533,12 @@ func main() {
 		}
 	}
 	buf := bytes.NewBuffer([]byte(""))
-	if err := tmplHeader.Execute(buf, unique(events)); err != nil {
+	typeMap := parseTypes(*fieldTypes)
+	obj := newTypeTree(events, typeMap, strings.Split(*tags, ",")...).objects()
+	if err := tmplHeader.Execute(buf, usesNumber(obj)); err != nil {
 		die(err)
 	}
-	typeMap := parseTypes(*fieldTypes)
-	if err := tmplTypes.Execute(buf,
-		newTypeTree(events, typeMap, strings.Split(*tags, ",")...).objects()); err != nil {
+	if err := tmplTypes.Execute(buf, obj); err != nil {
 		die(err)
 	}
 	b, err := format.Source(buf.Bytes())
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+// loadAllowlist sets the ranges of the allowlist to the configured ones.
+func loadAllowlist(a *webhook.Allowlist) error {
+	var err error
+	if config.AllowMeta != "" {
+		err = a.LoadMeta(config.AllowMeta, config.Allow...)
+	} else {
+		err = a.Set(config.Allow)
+	}
+	if err != nil {
+		return err
+	}
+	return a.SetTrustedProxies(config.Proxies)
+}
+
+// reloadAllowlist reloads the allowlist on each SIGHUP. The allowlist members
+// of the -config file are read again, as well as the -allow-meta file.
+func reloadAllowlist(a *webhook.Allowlist) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	for range ch {
+		if *configFile != "" {
+			p, err := ioutil.ReadFile(*configFile)
+			if err != nil {
+				log.Printf("ERROR reloading allowlist: %v", err)
+				continue
+			}
+			// Only the allowlist members are reloaded, the rest of the
+			// configuration requires a restart.
+			var c struct {
+				Allow     *list   `json:"allow"`
+				AllowMeta *string `json:"allowMeta"`
+				Proxies   *list   `json:"trustedProxies"`
+			}
+			if err := json.Unmarshal(p, &c); err != nil {
+				log.Printf("ERROR reloading allowlist: %v", err)
+				continue
+			}
+			if c.Allow != nil {
+				config.Allow = *c.Allow
+			}
+			if c.AllowMeta != nil {
+				config.AllowMeta = *c.AllowMeta
+			}
+			if c.Proxies != nil {
+				config.Proxies = *c.Proxies
+			}
+		}
+		if err := loadAllowlist(a); err != nil {
+			log.Printf("ERROR reloading allowlist: %v", err)
+			continue
+		}
+		log.Printf("INFO Reloaded allowlist: Ranges=%d Rejected=%d", a.Len(), a.Rejected())
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rjeczalik/gh/cmd/internal/tsc"
+	"github.com/rjeczalik/gh/webhook"
+)
+
+const deadLetterUsage = `usage: webhook deadletter [-json] dir
+       webhook deadletter -rerun [-secret key] [-url address | -script file] dir [id...]
+
+Lists the payloads, which failed to be handled after all the attempts and were
+written to the directory configured with the -dead-letter flag. Each line holds
+the time the payload was received, its event type, delivery ID, number of attempts,
+entry ID and the error of the last attempt.
+
+The -json flag prints the entries as a JSON array instead.
+
+The -rerun flag handles the given entries again, or all of them if no ID is
+provided. The payloads are either handled in-process by the -script template
+script, or signed with the -secret key and POSTed to the -url address. Each entry,
+which was handled successfully, is removed from the directory.
+
+Example
+
+In order to rerun all the failed payloads with a fixed script, run:
+
+	$ webhook deadletter -rerun -script fixed.tsc /var/lib/webhook/dead`
+
+func deadLetter(args []string) {
+	var (
+		r      = &replayer{client: http.DefaultClient}
+		script string
+		rerun  bool
+		asJSON bool
+	)
+	f := flag.NewFlagSet("deadletter", flag.ExitOnError)
+	f.Usage = func() {
+		fmt.Fprintln(os.Stderr, deadLetterUsage)
+	}
+	f.BoolVar(&asJSON, "json", false, "Prints entries as a JSON array.")
+	f.BoolVar(&rerun, "rerun", false, "Handles the entries again.")
+	f.StringVar(&r.secret, "secret", "", "GitHub secret value used for signing payloads.")
+	f.StringVar(&r.url, "url", "", "Address of the webhook to deliver payloads to.")
+	f.StringVar(&script, "script", "", "Template script handling payloads in-process.")
+	f.Parse(args)
+	if f.NArg() == 0 || !rerun && f.NArg() != 1 {
+		die(deadLetterUsage)
+	}
+	d := &webhook.DeadLetters{Dir: f.Arg(0)}
+	entries, err := d.List()
+	if err != nil {
+		die(err)
+	}
+	if !rerun {
+		if asJSON {
+			p, err := json.MarshalIndent(entries, "", "\t")
+			if err != nil {
+				die(err)
+			}
+			fmt.Printf("%s\n", p)
+			return
+		}
+		for _, e := range entries {
+			fmt.Printf("%s\t%s\t%s\t%d\t%s\t%s\n", e.ReceivedAt.UTC().Format(time.RFC3339), e.Event,
+				nonil(e.Delivery, "-"), e.Attempts, e.ID, e.Error)
+		}
+		return
+	}
+	switch {
+	case (r.url == "") == (script == ""):
+		die("exactly one of -url and -script flags must be provided")
+	case script != "":
+		sc, err := tsc.New(script, nil)
+		if err != nil {
+			die(err)
+		}
+		r.handler = webhook.New(nonil(r.secret, "deadletter"), sc)
+	case r.secret == "":
+		die("missing -secret flag")
+	}
+	ids := f.Args()[1:]
+	if len(ids) == 0 {
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+	}
+	var failed int
+	for _, id := range ids {
+		if err := r.rerun(d, id); err != nil {
+			failed++
+			log.Printf("ERROR %s: %v", id, err)
+			continue
+		}
+		log.Printf("INFO %s: handled", id)
+	}
+	log.Printf("INFO rerun %d payloads, %d failed", len(ids), failed)
+	if failed != 0 {
+		os.Exit(1)
+	}
+}
+
+// rerun handles the dead letter again and removes it, if it succeeds.
+func (r *replayer) rerun(d *webhook.DeadLetters, id string) error {
+	env, err := d.Get(id)
+	if err != nil {
+		return err
+	}
+	status, err := r.send(&delivery{Envelope: env, file: id})
+	if err == nil && status >= 400 {
+		err = fmt.Errorf("X-GitHub-Event=%q X-GitHub-Delivery=%q: Status=%d", env.Event, env.Delivery, status)
+	}
+	if err != nil {
+		return err
+	}
+	return d.Remove(id)
+}
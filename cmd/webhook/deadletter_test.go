@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+func TestDeadLetterRerun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	d, err := webhook.OpenDeadLetters(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, event := range []string{"push", "ping"} {
+		env := &webhook.Envelope{Event: event, Delivery: "1", ReceivedAt: time.Now(), Attempts: 3, Error: "exit status 1"}
+		env.SetBody([]byte(`{}`))
+		id, err := d.Put(env)
+		if err != nil {
+			t.Fatalf("Put()=%v", err)
+		}
+		ids = append(ids, id)
+	}
+	rec := &recorder{events: make(map[string][]string)}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-GitHub-Event") == "ping" {
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		rec.ServeHTTP(w, req)
+	}))
+	defer ts.Close()
+	r := &replayer{secret: secret, url: ts.URL, client: http.DefaultClient}
+	if err := r.rerun(d, ids[0]); err != nil {
+		t.Errorf("rerun()=%v", err)
+	}
+	if err := r.rerun(d, ids[1]); err == nil {
+		t.Error("want rerun to fail")
+	}
+	entries, err := d.List()
+	if err != nil {
+		t.Fatalf("List()=%v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != ids[1] || entries[0].Attempts != 3 {
+		t.Errorf("want only the failed entry to be left; got %+v", entries)
+	}
+	if len(rec.events["push"]) != 1 {
+		t.Errorf("want push event to be delivered; got %v", rec.events)
+	}
+}
@@ -6,6 +6,9 @@
 // Usage
 //
 //   webhook [-cert file -key file] [-addr address] [-log file] -secret key script
+//   webhook replay [-secret key] [-url address | -script file] path...
+//   webhook spool [-json] dir
+//   webhook deadletter [-json | -rerun [-secret key] [-url address | -script file]] dir
 //
 // The struct being passed to the template script is:
 //
@@ -32,6 +35,14 @@
 //   exec
 //   	An alias for exec.Command. Returned value is the process' output read
 //   	from its os.Stdout.
+//   branch, tag
+//   	Give the name of the branch or tag of the push, create or delete event
+//   	payload. Return empty string for other payloads.
+//   isTag, isBranchCreation, isBranchDeletion, isDefaultBranch
+//   	Aliases for the corresponding methods of the webhook.RefEvent interface.
+//   	Return false for payloads which do not implement it.
+//   shortSHA
+//   	An alias for webhook.ShortSHA.
 //
 // Example
 //
@@ -92,8 +103,132 @@
 //   - <event> is a value of X-GitHub-Event header
 //   - <delivery> is a value of X-GitHub-Delivery header
 //
+// If the -dump value ends with .ndjson, the payloads are appended to the given
+// file instead, one JSON object per line. If the value is an s3://bucket/prefix
+// URL, the payloads are uploaded to the S3 bucket. The credentials are read from
+// the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables and the
+// region from the AWS_REGION one. The -dump-s3-endpoint flag sets the URL of
+// an S3-compatible storage, e.g. http://localhost:9000 for a local MinIO server.
+//
+// The -envelope flag makes the dumped files hold the request headers, remote
+// address, time of receiving and the response status along with the payload.
+// See webhook.Envelope for the file format.
+//
+// The -dump-failed, -dump-events, -dump-repos and -dump-sample flags limit which
+// of the requests are dumped:
+//
+//   - -dump-failed dumps only requests, which were responded to with a status
+//     code of 400 or greater
+//   - -dump-events dumps only the comma-separated event types, e.g. push,create
+//   - -dump-repos dumps only payloads of the comma-separated repositories, each
+//     of them can be a path.Match pattern, e.g. rjeczalik/*
+//   - -dump-sample dumps only the given fraction of requests, e.g. 0.1
+//
+// The -dump-max-files, -dump-max-bytes and -dump-max-age flags configure retention
+// of the dumped files. When any of them is set, the oldest files are periodically
+// removed, so the dump directory does not grow over the limits. The limits can't
+// be used when dumping to an .ndjson file or s3:// URL. The -dump-gzip flag
+// compresses the dumped files and the -dump-daily flag writes them into daily
+// subdirectories, e.g. 2015-03-19.
+//
+// The -dump-redact flag redacts e-mails, names, logins, avatars and URLs of the
+// payloads before they are dumped. If the -dump-redact-key flag is provided, the
+// values are replaced with pseudonyms derived from the key, which are consistent
+// across the dumped payloads. Custom rules can be configured with the "dumpRedactRules"
+// member of the -config file, they take precedence over the default ones:
+//
+//   "dumpRedactRules": [
+//   	{"path": "commits.*.message", "anonymizer": "remove"},
+//   	{"path": "**.title", "anonymizer": "string"}
+//   ]
+//
+// See webhook.Redactor for details on the rules and built-in anonymizers.
+//
+// The -spool flag makes webhook write each verified payload to the given
+// directory, before responding with 202 Accepted. The payloads are handled after
+// they are synced to the disk, and they are removed once handled - payloads, which
+// were not handled before webhook exited, are handled after it is restarted.
+// The -spool-workers flag sets the number of payloads handled concurrently,
+// by default they are handled one at a time in the order they were received.
+// The spool subcommand lists payloads waiting in the spool directory.
+//
+// The -retry flag sets the maximum number of attempts of handling a payload,
+// when the script fails - either the template fails to execute or the bash script
+// exits with a non-zero status. The script is executed again after a backoff,
+// which starts at -retry-backoff and doubles with each attempt up to
+// -retry-max-backoff, and which is randomized by up to 50%. The "retries" member
+// of the -config file overrides the policy for the given events:
+//
+//   "retries": {
+//   	"push": {"attempts": 10, "backoff": "30s", "maxBackoff": "1h"}
+//   }
+//
+// The -dead-letter flag makes webhook write payloads, which failed after all
+// the attempts, to the given directory. The deadletter subcommand lists them and
+// handles them again with the -rerun flag, run webhook deadletter -help for details.
+//
+// The -allow flag makes webhook accept requests only from the given comma-separated
+// network ranges, e.g. 192.30.252.0/22,185.199.108.0/22. The -allow-meta flag reads
+// the ranges from the "hooks" member of a local copy of GitHub's meta API response:
+//
+//   $ curl -s https://api.github.com/meta > /etc/webhook/meta.json
+//
+// If webhook is behind a reverse proxy, the -trusted-proxies flag sets the proxy's
+// ranges, the client address of the requests, which come from them, is read from
+// the X-Forwarded-For header. The rejected requests are logged along with the number
+// of requests rejected so far. On SIGHUP the ranges are reloaded from the -allow-meta
+// file and the "allow", "allowMeta" and "trustedProxies" members of the -config file.
+//
+// The "rateLimits" member of the -config file limits the rate of payloads per
+// repository, sender or event type, e.g. to at most 10 comments per minute from
+// a single sender:
+//
+//   "rateLimits": [
+//   	{"by": "sender", "events": ["issue_comment"], "rate": 10, "per": "1m", "action": "drop"}
+//   ]
+//
+// The limits are token buckets, which allow bursts of up to "burst" payloads. The
+// "action" member configures what happens to the excess payloads: "drop" rejects them
+// with 429 Too Many Requests, "queue" delays handling them until they're within the
+// limit and "coalesce" handles only the latest of the delayed payloads of the same
+// event. At most "maxQueue" payloads are delayed per key, 100 by default. The delayed
+// payloads are kept in memory, thus with -spool the excess payloads are always rejected.
+//
+// The -max-payload flag sets the maximum size of a payload in bytes, by default it's
+// 25MiB - the maximum size of payloads delivered by GitHub. Larger requests, including
+// chunked ones without a Content-Length header, are rejected with 413 Request Entity
+// Too Large.
+//
 // The script argument is a path to the template script file which is used as a handler
 // for incoming events.
+//
+// Path routing
+//
+// The "paths" member of the -config file routes push events to template scripts
+// depending on which files the push changed:
+//
+//   {
+//   	"secret": "secret123",
+//   	"script": "all.tsc",
+//   	"paths": [
+//   		{"pattern": "services/api/**", "script": "api.tsc"},
+//   		{"pattern": "**/*.md", "script": "docs.tsc", "scriptArgs": ["-channel", "CH123"]}
+//   	]
+//   }
+//
+// Each of the path scripts is executed at most once per push event, if at least
+// one of the changed files matches its pattern. The "**" element of a pattern
+// matches zero or more directories, the rest of the elements are matched with
+// the path.Match function. The script argument is optional when paths are
+// configured.
+//
+// Replay
+//
+// The replay subcommand re-delivers the payloads dumped with the -dump flag:
+//
+//   $ webhook replay -secret secret123 -url http://localhost:8080 -event push -since 1h /tmp/dumps
+//
+// Run webhook replay -help for details.
 package main
 
 import (
@@ -105,14 +240,22 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/rjeczalik/gh/cmd/internal/tsc"
 	"github.com/rjeczalik/gh/webhook"
+	"golang.org/x/net/context"
 )
 
 const usage = `usage: webhook [-cert file -key file] [-addr address] [-log file] -secret key script
+       webhook replay [-secret key] [-url address | -script file] path...
+       webhook spool [-json] dir
+       webhook deadletter [-json | -rerun [-secret key] [-url address | -script file]] dir
 
 Starts a web server which listens on GitHub's POST requests. The payload of each
 request is verified against its signature, unmarshalled into corresponding event
@@ -143,6 +286,14 @@ script has registered extra control functions:
 	exec
 		An alias for exec.Command. Returned value is the process' output read
 		from its os.Stdout.
+	branch, tag
+		Give the name of the branch or tag of the push, create or delete event
+		payload. Return empty string for other payloads.
+	isTag, isBranchCreation, isBranchDeletion, isDefaultBranch
+		Aliases for the corresponding methods of the webhook.RefEvent interface.
+		Return false for payloads which do not implement it.
+	shortSHA
+		An alias for webhook.ShortSHA.
 
 Example
 
@@ -203,21 +354,327 @@ directory. The file is named after <event>-<delivery>.json, where:
 	- <event> is a value of X-GitHub-Event header
 	- <delivery> is a value of X-GitHub-Delivery header
 
+If the -dump value ends with .ndjson, the payloads are appended to the given
+file instead, one JSON object per line. If the value is an s3://bucket/prefix
+URL, the payloads are uploaded to the S3 bucket. The credentials are read from
+the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables and the
+region from the AWS_REGION one. The -dump-s3-endpoint flag sets the URL of
+an S3-compatible storage, e.g. http://localhost:9000 for a local MinIO server.
+
+The -envelope flag makes the dumped files hold the request headers, remote
+address, time of receiving and the response status along with the payload.
+See webhook.Envelope for the file format.
+
+The -dump-failed, -dump-events, -dump-repos and -dump-sample flags limit which
+of the requests are dumped:
+
+	- -dump-failed dumps only requests, which were responded to with a status
+	  code of 400 or greater
+	- -dump-events dumps only the comma-separated event types, e.g. push,create
+	- -dump-repos dumps only payloads of the comma-separated repositories, each
+	  of them can be a path.Match pattern, e.g. rjeczalik/*
+	- -dump-sample dumps only the given fraction of requests, e.g. 0.1
+
+The -dump-max-files, -dump-max-bytes and -dump-max-age flags configure retention
+of the dumped files. When any of them is set, the oldest files are periodically
+removed, so the dump directory does not grow over the limits. The limits can't
+be used when dumping to an .ndjson file or s3:// URL. The -dump-gzip flag
+compresses the dumped files and the -dump-daily flag writes them into daily
+subdirectories, e.g. 2015-03-19.
+
+The -dump-redact flag redacts e-mails, names, logins, avatars and URLs of the
+payloads before they are dumped. If the -dump-redact-key flag is provided, the
+values are replaced with pseudonyms derived from the key, which are consistent
+across the dumped payloads. Custom rules can be configured with the "dumpRedactRules"
+member of the -config file, they take precedence over the default ones:
+
+	"dumpRedactRules": [
+		{"path": "commits.*.message", "anonymizer": "remove"},
+		{"path": "**.title", "anonymizer": "string"}
+	]
+
+See webhook.Redactor for details on the rules and built-in anonymizers.
+
+The -spool flag makes webhook write each verified payload to the given
+directory, before responding with 202 Accepted. The payloads are handled after
+they are synced to the disk, and they are removed once handled - payloads, which
+were not handled before webhook exited, are handled after it is restarted.
+The -spool-workers flag sets the number of payloads handled concurrently,
+by default they are handled one at a time in the order they were received.
+The spool subcommand lists payloads waiting in the spool directory.
+
+The -retry flag sets the maximum number of attempts of handling a payload,
+when the script fails - either the template fails to execute or the bash script
+exits with a non-zero status. The script is executed again after a backoff,
+which starts at -retry-backoff and doubles with each attempt up to
+-retry-max-backoff, and which is randomized by up to 50%. The "retries" member
+of the -config file overrides the policy for the given events:
+
+	"retries": {
+		"push": {"attempts": 10, "backoff": "30s", "maxBackoff": "1h"}
+	}
+
+The -dead-letter flag makes webhook write payloads, which failed after all
+the attempts, to the given directory. The deadletter subcommand lists them and
+handles them again with the -rerun flag, run webhook deadletter -help for details.
+
+The -allow flag makes webhook accept requests only from the given comma-separated
+network ranges, e.g. 192.30.252.0/22,185.199.108.0/22. The -allow-meta flag reads
+the ranges from the "hooks" member of a local copy of GitHub's meta API response:
+
+	$ curl -s https://api.github.com/meta > /etc/webhook/meta.json
+
+If webhook is behind a reverse proxy, the -trusted-proxies flag sets the proxy's
+ranges, the client address of the requests, which come from them, is read from
+the X-Forwarded-For header. The rejected requests are logged along with the number
+of requests rejected so far. On SIGHUP the ranges are reloaded from the -allow-meta
+file and the "allow", "allowMeta" and "trustedProxies" members of the -config file.
+
+The "rateLimits" member of the -config file limits the rate of payloads per
+repository, sender or event type, e.g. to at most 10 comments per minute from
+a single sender:
+
+	"rateLimits": [
+		{"by": "sender", "events": ["issue_comment"], "rate": 10, "per": "1m", "action": "drop"}
+	]
+
+The limits are token buckets, which allow bursts of up to "burst" payloads. The
+"action" member configures what happens to the excess payloads: "drop" rejects them
+with 429 Too Many Requests, "queue" delays handling them until they're within the
+limit and "coalesce" handles only the latest of the delayed payloads of the same
+event. At most "maxQueue" payloads are delayed per key, 100 by default. The delayed
+payloads are kept in memory, thus with -spool the excess payloads are always rejected.
+
+The -max-payload flag sets the maximum size of a payload in bytes, by default it's
+25MiB - the maximum size of payloads delivered by GitHub. Larger requests, including
+chunked ones without a Content-Length header, are rejected with 413 Request Entity
+Too Large.
+
 The script argument is a path to the template script file which is used as a handler
-for incoming events.`
+for incoming events.
+
+Path routing
+
+The "paths" member of the -config file routes push events to template scripts
+depending on which files the push changed:
+
+	{
+		"secret": "secret123",
+		"script": "all.tsc",
+		"paths": [
+			{"pattern": "services/api/**", "script": "api.tsc"},
+			{"pattern": "**/*.md", "script": "docs.tsc", "scriptArgs": ["-channel", "CH123"]}
+		]
+	}
+
+Each of the path scripts is executed at most once per push event, if at least
+one of the changed files matches its pattern. The "**" element of a pattern
+matches zero or more directories, the rest of the elements are matched with
+the path.Match function. The script argument is optional when paths are
+configured.
+
+Replay
+
+The replay subcommand re-delivers the payloads dumped with the -dump flag:
+
+	$ webhook replay -secret secret123 -url http://localhost:8080 -event push -since 1h /tmp/dumps
+
+Run webhook replay -help for details.`
 
 var config struct {
-	Cert       string   `json:"cert"`
-	Key        string   `json:"key"`
-	Addr       string   `json:"addr"`
-	Secret     string   `json:"secret"`
-	Debug      bool     `json:"debug"`
-	Dump       string   `json:"dump"`
-	Log        string   `json:"log"`
+	Cert       string       `json:"cert"`
+	Key        string       `json:"key"`
+	Addr       string       `json:"addr"`
+	Secret     string       `json:"secret"`
+	MaxPayload int64        `json:"maxPayload"`
+	Debug      bool         `json:"debug"`
+	Dump       string       `json:"dump"`
+	Envelope   bool         `json:"envelope"`
+	DumpFailed bool         `json:"dumpFailed"`
+	DumpEvents list         `json:"dumpEvents"`
+	DumpRepos  list         `json:"dumpRepos"`
+	DumpSample float64      `json:"dumpSample"`
+	DumpGzip   bool         `json:"dumpGzip"`
+	DumpDaily  bool         `json:"dumpDaily"`
+	MaxFiles   int          `json:"dumpMaxFiles"`
+	MaxBytes   int64        `json:"dumpMaxBytes"`
+	MaxAge     duration     `json:"dumpMaxAge"`
+	Redact     bool         `json:"dumpRedact"`
+	RedactKey  string       `json:"dumpRedactKey"`
+	Redactions []redactRule `json:"dumpRedactRules"`
+	S3Endpoint string       `json:"dumpS3Endpoint"`
+	Spool      string       `json:"spool"`
+	Workers    int          `json:"spoolWorkers"`
+	Retry      int          `json:"retry"`
+	Backoff    duration     `json:"retryBackoff"`
+	MaxBackoff duration     `json:"retryMaxBackoff"`
+	Retries    retryRules   `json:"retries"`
+	DeadLetter string       `json:"deadLetter"`
+	Allow      list         `json:"allow"`
+	AllowMeta  string       `json:"allowMeta"`
+	Proxies    list         `json:"trustedProxies"`
+	RateLimits rateLimits   `json:"rateLimits"`
+	Log        string       `json:"log"`
+	Script     string       `json:"script"`
+	ScriptArgs []string     `json:"scriptArgs"`
+	Paths      []pathScript `json:"paths"`
+}
+
+// list is a flag.Value, which holds comma-separated values.
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(s string) error {
+	*l = nil
+	for _, s := range strings.Split(s, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
+// duration is a time.Duration, which is encoded as a string, e.g. "72h".
+type duration time.Duration
+
+func (d *duration) String() string {
+	return time.Duration(*d).String()
+}
+
+func (d *duration) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(p []byte) error {
+	var s string
+	if err := json.Unmarshal(p, &s); err != nil {
+		return err
+	}
+	return d.Set(s)
+}
+
+// redactRule configures a webhook.RedactRule, the anonymizer is a key of
+// the webhook.Anonymizers map.
+type redactRule struct {
+	Path       string   `json:"path"`
+	Anonymizer string   `json:"anonymizer"`
+	Requires   []string `json:"requires"`
+}
+
+// retryPolicy configures a webhook.RetryPolicy for an event.
+type retryPolicy struct {
+	Attempts   int      `json:"attempts"`
+	Backoff    duration `json:"backoff"`
+	MaxBackoff duration `json:"maxBackoff"`
+}
+
+// retryRules maps names of events to their retry policies.
+type retryRules map[string]retryPolicy
+
+func (p retryPolicy) policy() *webhook.RetryPolicy {
+	return &webhook.RetryPolicy{
+		MaxAttempts: p.Attempts,
+		MinBackoff:  time.Duration(p.Backoff),
+		MaxBackoff:  time.Duration(p.MaxBackoff),
+		Jitter:      0.5,
+	}
+}
+
+// rateLimit configures a webhook.RateLimit, the rate is a number of payloads
+// allowed per the given period.
+type rateLimit struct {
+	By       string   `json:"by"`
+	Events   []string `json:"events"`
+	Rate     float64  `json:"rate"`
+	Per      duration `json:"per"`
+	Burst    int      `json:"burst"`
+	Action   string   `json:"action"`
+	MaxQueue int      `json:"maxQueue"`
+}
+
+// rateLimits lists the rate limits, which are applied to each delivery.
+type rateLimits []rateLimit
+
+var rateLimitActions = map[string]webhook.RateLimitAction{
+	"":         webhook.RateLimitDrop,
+	"drop":     webhook.RateLimitDrop,
+	"queue":    webhook.RateLimitQueue,
+	"coalesce": webhook.RateLimitCoalesce,
+}
+
+func (r rateLimit) limit() (*webhook.RateLimit, error) {
+	switch r.By {
+	case webhook.RateByRepository, webhook.RateBySender, webhook.RateByEvent:
+	default:
+		return nil, fmt.Errorf("invalid rate limit key %q", r.By)
+	}
+	action, ok := rateLimitActions[r.Action]
+	if !ok {
+		return nil, fmt.Errorf("invalid rate limit action %q", r.Action)
+	}
+	if r.Rate <= 0 {
+		return nil, fmt.Errorf("invalid rate %v for %q rate limit", r.Rate, r.By)
+	}
+	per := time.Duration(r.Per)
+	if per <= 0 {
+		per = time.Second
+	}
+	return &webhook.RateLimit{
+		By:       r.By,
+		Events:   r.Events,
+		Rate:     r.Rate / per.Seconds(),
+		Burst:    r.Burst,
+		Action:   action,
+		MaxQueue: r.MaxQueue,
+	}, nil
+}
+
+// pathScript configures a template script for handling push events, which changed
+// files matching the pattern.
+type pathScript struct {
+	Pattern    string   `json:"pattern"`
 	Script     string   `json:"script"`
 	ScriptArgs []string `json:"scriptArgs"`
 }
 
+// service handles all events with the script and additionally routes push
+// events with the mux.
+type service struct {
+	*tsc.Script
+	mux *webhook.PathMux
+}
+
+// Push executes the path scripts only once, they're not retried when the script
+// fails.
+func (s service) Push(ctx context.Context, e *webhook.PushEvent) error {
+	if webhook.Attempt(ctx) == 1 {
+		s.mux.Push(e)
+	}
+	return s.Script.Webhook("push", e)
+}
+
+// pathService routes push events with the mux, it's used when no -script
+// is configured.
+type pathService struct {
+	mux *webhook.PathMux
+}
+
+func (s pathService) Push(e *webhook.PushEvent) {
+	s.mux.Push(e)
+}
+
 var configFile = flag.String("config", "", "Configuration file to use.")
 
 func init() {
@@ -225,8 +682,31 @@ func init() {
 	flag.StringVar(&config.Key, "key", "", "Private key file.")
 	flag.StringVar(&config.Addr, "addr", "", "Network address to listen on. Default is :8080 for HTTP and :8443 for HTTPS.")
 	flag.StringVar(&config.Secret, "secret", "", "GitHub secret value used for signing payloads.")
+	flag.Int64Var(&config.MaxPayload, "max-payload", 0, "Maximum size of a payload in bytes. Default is 25MiB.")
 	flag.BoolVar(&config.Debug, "debug", false, "Dumps verified payloads into testdata directory.")
-	flag.StringVar(&config.Dump, "dump", "", "Dumps verified payloads into given directory.")
+	flag.StringVar(&config.Dump, "dump", "", "Dumps verified payloads into given directory, .ndjson file or s3:// URL.")
+	flag.StringVar(&config.S3Endpoint, "dump-s3-endpoint", "", "URL of S3-compatible storage for s3:// dumps.")
+	flag.BoolVar(&config.Envelope, "envelope", false, "Dumps request details along with payloads.")
+	flag.BoolVar(&config.DumpFailed, "dump-failed", false, "Dumps only failed requests.")
+	flag.Var(&config.DumpEvents, "dump-events", "Dumps only the given comma-separated event types.")
+	flag.Var(&config.DumpRepos, "dump-repos", "Dumps only payloads of the given comma-separated repositories.")
+	flag.Float64Var(&config.DumpSample, "dump-sample", 0, "Dumps only the given fraction of requests.")
+	flag.BoolVar(&config.DumpGzip, "dump-gzip", false, "Compresses dumped files with gzip.")
+	flag.BoolVar(&config.DumpDaily, "dump-daily", false, "Dumps files into daily subdirectories.")
+	flag.IntVar(&config.MaxFiles, "dump-max-files", 0, "Maximum number of dumped files to keep.")
+	flag.Int64Var(&config.MaxBytes, "dump-max-bytes", 0, "Maximum total size of dumped files to keep.")
+	flag.Var(&config.MaxAge, "dump-max-age", "Maximum age of dumped files to keep, e.g. 72h.")
+	flag.BoolVar(&config.Redact, "dump-redact", false, "Redacts sensitive values of dumped payloads.")
+	flag.StringVar(&config.RedactKey, "dump-redact-key", "", "Key for consistent pseudonyms of redacted values.")
+	flag.StringVar(&config.Spool, "spool", "", "Spools verified payloads in the given directory before handling them.")
+	flag.IntVar(&config.Workers, "spool-workers", 0, "Number of payloads handled concurrently from the spool.")
+	flag.IntVar(&config.Retry, "retry", 0, "Maximum number of attempts of handling a payload.")
+	flag.Var(&config.Backoff, "retry-backoff", "Backoff after the first failed attempt, e.g. 1s.")
+	flag.Var(&config.MaxBackoff, "retry-max-backoff", "Maximum backoff between attempts, e.g. 5m.")
+	flag.StringVar(&config.DeadLetter, "dead-letter", "", "Writes payloads, which failed after all attempts, to the given directory.")
+	flag.Var(&config.Allow, "allow", "Accepts requests only from the given comma-separated network ranges.")
+	flag.StringVar(&config.AllowMeta, "allow-meta", "", "Accepts requests only from the hooks ranges of the given GitHub's meta file.")
+	flag.Var(&config.Proxies, "trusted-proxies", "Comma-separated network ranges of trusted reverse proxies.")
 	flag.StringVar(&config.Log, "log", "", "Redirects output to the given file.")
 }
 
@@ -239,12 +719,57 @@ func nonil(s ...string) string {
 	return ""
 }
 
+// dumper creates Dumper for the -dump value, which is either a directory,
+// an .ndjson file or an s3://bucket/prefix URL.
+func dumper(dump string, handler http.Handler) *webhook.Dumper {
+	switch {
+	case strings.HasPrefix(dump, "s3://"):
+		u, err := url.Parse(dump)
+		if err != nil {
+			die(err)
+		}
+		prefix := strings.TrimPrefix(u.Path, "/")
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		region := nonil(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1")
+		return webhook.DumpTo(&webhook.S3Store{
+			Endpoint:  nonil(config.S3Endpoint, "https://s3."+region+".amazonaws.com"),
+			Bucket:    u.Host,
+			Prefix:    prefix,
+			Region:    region,
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		}, handler)
+	case strings.HasSuffix(dump, ".ndjson"):
+		s, err := webhook.NewNDJSONStore(dump)
+		if err != nil {
+			die(err)
+		}
+		return webhook.DumpTo(s, handler)
+	default:
+		return webhook.Dump(dump, handler)
+	}
+}
+
 func die(v interface{}) {
 	fmt.Fprintln(os.Stderr, v)
 	os.Exit(1)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		replay(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "spool" {
+		spool(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "deadletter" {
+		deadLetter(os.Args[2:])
+		return
+	}
 	if len(os.Args) == 1 {
 		die(usage)
 	}
@@ -272,12 +797,37 @@ func main() {
 			die(err)
 		}
 	}
-	if config.Script == "" {
+	if config.Script == "" && len(config.Paths) == 0 {
 		die("missing script file")
 	}
 	if (config.Cert == "") != (config.Key == "") {
 		die("both -cert and -key flags must be provided")
 	}
+	for _, pattern := range config.DumpRepos {
+		if _, err := path.Match(pattern, ""); err != nil {
+			die(fmt.Sprintf("invalid repository pattern %q: %s", pattern, err))
+		}
+	}
+	if config.DumpSample < 0 || config.DumpSample > 1 {
+		die("the -dump-sample value must be within [0, 1]")
+	}
+	var redactor *webhook.Redactor
+	if config.Redact || config.RedactKey != "" || len(config.Redactions) != 0 {
+		redactor = &webhook.Redactor{Key: []byte(config.RedactKey)}
+		for _, rule := range config.Redactions {
+			fn, ok := webhook.Anonymizers[rule.Anonymizer]
+			if !ok {
+				die(fmt.Sprintf("unknown anonymizer %q for %q redact rule", rule.Anonymizer, rule.Path))
+			}
+			redactor.Rules = append(redactor.Rules, webhook.RedactRule{Path: rule.Path, Anonymizer: fn, Requires: rule.Requires})
+		}
+		if len(redactor.Rules) != 0 {
+			redactor.Rules = append(redactor.Rules, webhook.DefaultRedactRules...)
+		}
+		if err := redactor.Validate(); err != nil {
+			die(err)
+		}
+	}
 	if config.Debug && config.Dump == "" {
 		config.Dump = "testdata"
 	}
@@ -297,9 +847,35 @@ func main() {
 		log.SetOutput(f)
 		defer f.Close()
 	}
-	sc, err := tsc.New(config.Script, config.ScriptArgs)
-	if err != nil {
-		die(err)
+	var rcvr interface{}
+	if config.Script != "" {
+		sc, err := tsc.New(config.Script, config.ScriptArgs)
+		if err != nil {
+			die(err)
+		}
+		rcvr = sc
+	}
+	if len(config.Paths) != 0 {
+		mux := webhook.NewPathMux()
+		for _, p := range config.Paths {
+			if _, err := webhook.MatchPath(p.Pattern, ""); err != nil {
+				die(fmt.Sprintf("invalid path pattern %q: %s", p.Pattern, err))
+			}
+			sc, err := tsc.New(p.Script, p.ScriptArgs)
+			if err != nil {
+				die(err)
+			}
+			mux.Handle(p.Pattern, func(e *webhook.PushEvent) {
+				if err := sc.Webhook("push", e); err != nil {
+					log.Printf("ERROR %v", err)
+				}
+			})
+		}
+		if sc, ok := rcvr.(*tsc.Script); ok {
+			rcvr = service{Script: sc, mux: mux}
+		} else {
+			rcvr = pathService{mux: mux}
+		}
 	}
 	var listener net.Listener
 	if config.Cert != "" {
@@ -322,9 +898,69 @@ func main() {
 		}
 		listener = l
 	}
-	var handler http.Handler = webhook.New(config.Secret, sc)
+	h := webhook.New(config.Secret, rcvr)
+	h.MaxPayload = config.MaxPayload
+	if config.Retry > 1 {
+		h.Retry = retryPolicy{config.Retry, config.Backoff, config.MaxBackoff}.policy()
+	}
+	if len(config.Retries) != 0 {
+		h.Retries = make(map[string]*webhook.RetryPolicy, len(config.Retries))
+		for event, p := range config.Retries {
+			h.Retries[event] = p.policy()
+		}
+	}
+	if len(config.Allow) != 0 || config.AllowMeta != "" {
+		a := &webhook.Allowlist{}
+		if err := loadAllowlist(a); err != nil {
+			die(err)
+		}
+		h.Allowlist = a
+		go reloadAllowlist(a)
+	}
+	for _, r := range config.RateLimits {
+		rl, err := r.limit()
+		if err != nil {
+			die(err)
+		}
+		h.RateLimits = append(h.RateLimits, rl)
+	}
+	if config.DeadLetter != "" {
+		d, err := webhook.OpenDeadLetters(config.DeadLetter)
+		if err != nil {
+			die(err)
+		}
+		h.DeadLetters = d
+	}
+	if config.Spool != "" {
+		s, err := webhook.OpenSpool(config.Spool)
+		if err != nil {
+			die(err)
+		}
+		h.Spool = s
+		h.Workers = config.Workers
+		if n := s.Len(); n != 0 {
+			log.Printf("INFO Handling %d payloads left in %s . . .", n, config.Spool)
+		}
+		h.Start()
+	}
+	var handler http.Handler = h
 	if config.Dump != "" {
-		handler = webhook.Dump(config.Dump, handler)
+		d := dumper(config.Dump, handler)
+		d.Envelope = config.Envelope
+		d.Failed = config.DumpFailed
+		d.Events = config.DumpEvents
+		d.Repos = config.DumpRepos
+		d.Sample = config.DumpSample
+		d.Compress = config.DumpGzip
+		d.Daily = config.DumpDaily
+		d.MaxFiles = config.MaxFiles
+		d.MaxBytes = config.MaxBytes
+		d.MaxAge = time.Duration(config.MaxAge)
+		if d.Store != nil && (d.MaxFiles > 0 || d.MaxBytes > 0 || d.MaxAge > 0) {
+			die("the -dump-max-files, -dump-max-bytes and -dump-max-age flags require -dump to be a directory")
+		}
+		d.Redactor = redactor
+		handler = d
 	}
 	log.Printf("INFO Listening on %s . . .", listener.Addr())
 	if err := http.Serve(listener, handler); err != nil {
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+func TestRateLimitConfig(t *testing.T) {
+	p := []byte(`[
+		{"by": "sender", "events": ["issue_comment"], "rate": 30, "per": "1m", "action": "coalesce"},
+		{"by": "repository", "rate": 5}
+	]`)
+	var r rateLimits
+	if err := json.Unmarshal(p, &r); err != nil {
+		t.Fatalf("Unmarshal()=%v", err)
+	}
+	rl, err := r[0].limit()
+	if err != nil {
+		t.Fatalf("limit()=%v", err)
+	}
+	if rl.Rate != 0.5 || rl.Action != webhook.RateLimitCoalesce || len(rl.Events) != 1 {
+		t.Errorf("want Rate=0.5 Action=coalesce Events=[issue_comment]; got %+v", rl)
+	}
+	if rl, err = r[1].limit(); err != nil {
+		t.Fatalf("limit()=%v", err)
+	}
+	if rl.Rate != 5 || rl.Action != webhook.RateLimitDrop {
+		t.Errorf("want Rate=5 Action=drop; got %+v", rl)
+	}
+	for _, r := range []rateLimit{
+		{By: "org", Rate: 1},
+		{By: "sender", Rate: 1, Action: "retry"},
+		{By: "sender"},
+	} {
+		if _, err := r.limit(); err == nil {
+			t.Errorf("want limit() to fail for %+v", r)
+		}
+	}
+}
+
+func TestPathService(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	webhook.New("secret", pathService{mux: webhook.NewPathMux()})
+	if buf.Len() != 0 {
+		t.Errorf("want no warnings; got %q", buf.String())
+	}
+}
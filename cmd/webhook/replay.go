@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rjeczalik/gh/cmd/internal/tsc"
+	"github.com/rjeczalik/gh/webhook"
+)
+
+const replayUsage = `usage: webhook replay [-secret key] [-url address | -script file] [-rate n]
+                      [-event list] [-repo list] [-since time] [-until time] [-dry-run] path...
+
+Re-delivers the payloads dumped with the -dump flag. Each path is either a dumped
+file, a directory with the dumped files or a glob pattern, e.g. 'dumps/push-*'.
+Both plain and enveloped files are supported, as well as .gz and .ndjson ones.
+
+Each payload is signed with the -secret key and POSTed to the -url address.
+The original X-GitHub-Event and X-GitHub-Delivery headers are preserved. If the
+-script flag is provided instead, the payloads are handled in-process by the
+template script, in the same way the webhook command does.
+
+The payloads are replayed in the order they were received. The -rate flag limits
+the number of payloads delivered per second.
+
+The -event and -repo flags replay only payloads of the given comma-separated event
+types and repositories, the repositories can be path.Match patterns, e.g. rjeczalik/*.
+The -since and -until flags replay only payloads received within the given time
+range. The time is either an RFC 3339 timestamp, a date, e.g. 2015-03-19, or
+a duration, which is relative to the current time, e.g. 24h.
+
+The -dry-run flag lists the payloads, which would be replayed, without sending them.
+
+Example
+
+In order to reproduce failed push deliveries of the last hour locally, run:
+
+	$ webhook replay -secret secret123 -url http://localhost:8080 -event push -since 1h /tmp/dumps`
+
+// delivery is a dumped request read by the replay command. Plain dumps are
+// read into envelopes without headers.
+type delivery struct {
+	*webhook.Envelope
+	file string // file the delivery was read from
+}
+
+// timeFlag is a flag.Value, which holds a point in time.
+type timeFlag struct {
+	time.Time
+}
+
+func (t *timeFlag) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (t *timeFlag) Set(s string) error {
+	if d, err := time.ParseDuration(s); err == nil {
+		t.Time = time.Now().Add(-d)
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if v, err := time.Parse(layout, s); err == nil {
+			t.Time = v
+			return nil
+		}
+	}
+	return errors.New("invalid time " + s)
+}
+
+// replayer sends the deliveries either to the url or the handler.
+type replayer struct {
+	secret  string
+	url     string
+	handler *webhook.Handler
+	client  *http.Client
+	events  list
+	repos   list
+	since   timeFlag
+	until   timeFlag
+}
+
+func replay(args []string) {
+	var (
+		r      = &replayer{client: http.DefaultClient}
+		script string
+		rate   float64
+		dryRun bool
+	)
+	f := flag.NewFlagSet("replay", flag.ExitOnError)
+	f.Usage = func() {
+		fmt.Fprintln(os.Stderr, replayUsage)
+	}
+	f.StringVar(&r.secret, "secret", "", "GitHub secret value used for signing payloads.")
+	f.StringVar(&r.url, "url", "", "Address of the webhook to deliver payloads to.")
+	f.StringVar(&script, "script", "", "Template script handling payloads in-process.")
+	f.Float64Var(&rate, "rate", 0, "Maximum number of payloads delivered per second.")
+	f.Var(&r.events, "event", "Replays only the given comma-separated event types.")
+	f.Var(&r.repos, "repo", "Replays only payloads of the given comma-separated repositories.")
+	f.Var(&r.since, "since", "Replays only payloads received after the given time.")
+	f.Var(&r.until, "until", "Replays only payloads received before the given time.")
+	f.BoolVar(&dryRun, "dry-run", false, "Lists payloads without replaying them.")
+	f.Parse(args)
+	if f.NArg() == 0 {
+		die(replayUsage)
+	}
+	for _, pattern := range r.repos {
+		if _, err := path.Match(pattern, ""); err != nil {
+			die(fmt.Sprintf("invalid repository pattern %q: %s", pattern, err))
+		}
+	}
+	switch {
+	case dryRun:
+	case (r.url == "") == (script == ""):
+		die("exactly one of -url and -script flags must be provided")
+	case script != "":
+		sc, err := tsc.New(script, nil)
+		if err != nil {
+			die(err)
+		}
+		r.secret = nonil(r.secret, "replay")
+		r.handler = webhook.New(r.secret, sc)
+	case r.secret == "":
+		die("missing -secret flag")
+	}
+	deliveries, err := readDeliveries(f.Args())
+	if err != nil {
+		die(err)
+	}
+	var tick <-chan time.Time
+	if rate > 0 {
+		t := time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer t.Stop()
+		tick = t.C
+	}
+	var n, failed int
+	for _, d := range deliveries {
+		if !r.match(d) {
+			continue
+		}
+		if dryRun {
+			fmt.Printf("%s\t%s\t%s\t%s\n", d.ReceivedAt.UTC().Format(time.RFC3339), d.Event, nonil(d.Delivery, "-"), d.file)
+			continue
+		}
+		if tick != nil && n != 0 {
+			<-tick
+		}
+		n++
+		status, err := r.send(d)
+		switch {
+		case err != nil:
+			failed++
+			log.Printf("ERROR %s: X-GitHub-Event=%q X-GitHub-Delivery=%q: %v", d.file, d.Event, d.Delivery, err)
+		case status >= 400:
+			failed++
+			log.Printf("ERROR %s: Status=%d X-GitHub-Event=%q X-GitHub-Delivery=%q", d.file, status, d.Event, d.Delivery)
+		default:
+			log.Printf("INFO %s: Status=%d X-GitHub-Event=%q X-GitHub-Delivery=%q", d.file, status, d.Event, d.Delivery)
+		}
+	}
+	if !dryRun {
+		log.Printf("INFO replayed %d payloads, %d failed", n, failed)
+	}
+	if failed != 0 {
+		os.Exit(1)
+	}
+}
+
+// match reports whether the delivery passes the filters.
+func (r *replayer) match(d *delivery) bool {
+	if len(r.events) != 0 && !contains(r.events, d.Event) {
+		return false
+	}
+	if !r.since.IsZero() && d.ReceivedAt.Before(r.since.Time) {
+		return false
+	}
+	if !r.until.IsZero() && d.ReceivedAt.After(r.until.Time) {
+		return false
+	}
+	if len(r.repos) != 0 {
+		var v struct {
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+		}
+		json.Unmarshal(d.Payload(), &v)
+		for _, pattern := range r.repos {
+			if ok, _ := path.Match(pattern, v.Repository.FullName); ok && v.Repository.FullName != "" {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// send signs the delivery and sends it, it gives the status code of
+// the response. If the handler is set, the delivery is handled in-process
+// instead and send returns after it was handled.
+func (r *replayer) send(d *delivery) (int, error) {
+	if r.handler != nil {
+		if err := r.handler.Dispatch(d.Envelope); err != nil {
+			return 0, err
+		}
+		return http.StatusNoContent, nil
+	}
+	req, err := d.Request(nonil(r.url, "/"))
+	if err != nil {
+		return 0, err
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	body := d.Payload()
+	req.Header.Set("X-Hub-Signature", "sha1="+hexDigest(sha1.New, r.secret, body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hexDigest(sha256.New, r.secret, body))
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func hexDigest(h func() hash.Hash, secret string, p []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(p)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// readDeliveries reads the deliveries from the files, directories or glob
+// patterns. The deliveries are sorted by the time they were received.
+func readDeliveries(paths []string) ([]*delivery, error) {
+	var files []string
+	for _, p := range paths {
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, errors.New(p + ": no such file or directory")
+		}
+		for _, m := range matches {
+			err := filepath.Walk(m, func(path string, fi os.FileInfo, err error) error {
+				if err == nil && fi.Mode().IsRegular() {
+					files = append(files, path)
+				}
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	var deliveries []*delivery
+	for _, file := range files {
+		d, err := readFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+		deliveries = append(deliveries, d...)
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].ReceivedAt.Before(deliveries[j].ReceivedAt)
+	})
+	return deliveries, nil
+}
+
+// readFile reads the deliveries from the dumped file. The .ndjson files
+// hold a delivery per line - either an envelope or a record written by
+// the webhook.NDJSONStore, the rest of the files hold a single one.
+func readFile(file string) ([]*delivery, error) {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(file, ".ndjson") {
+		d, err := parseDump(filepath.Base(file), p, fi.ModTime())
+		if err != nil {
+			return nil, err
+		}
+		d.file = file
+		return []*delivery{d}, nil
+	}
+	var deliveries []*delivery
+	scanner := bufio.NewScanner(bytes.NewReader(p))
+	scanner.Buffer(nil, len(p)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		d, err := parseLine(scanner.Bytes(), fi.ModTime())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		d.file = fmt.Sprintf("%s:%d", file, line)
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, scanner.Err()
+}
+
+// parseLine parses a single line of the .ndjson file.
+func parseLine(p []byte, mod time.Time) (*delivery, error) {
+	switch env, err := webhook.ReadEnvelope(p); err {
+	case nil:
+		return &delivery{Envelope: env}, nil
+	case webhook.ErrNotEnvelope:
+	default:
+		return nil, err
+	}
+	var rec struct {
+		Name    string          `json:"name"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(p, &rec); err != nil {
+		return nil, err
+	}
+	payload := []byte(rec.Payload)
+	if len(payload) != 0 && payload[0] == '"' {
+		if err := json.Unmarshal(rec.Payload, &payload); err != nil {
+			return nil, err
+		}
+	}
+	return parseDump(rec.Name, payload, mod)
+}
+
+// parseDump parses the dumped payload, which was written under the given name,
+// e.g. push-<delivery>.json.gz. The payload is either a request body or
+// an envelope written by the Dumper.
+func parseDump(name string, p []byte, mod time.Time) (*delivery, error) {
+	var event, id string
+	base := strings.TrimSuffix(strings.TrimSuffix(path.Base(name), ".gz"), ".json")
+	if i := strings.IndexByte(base, '-'); i != -1 && strings.Trim(base[:i], "abcdefghijklmnopqrstuvwxyz_") == "" {
+		event, id = base[:i], base[i+1:]
+		if strings.Contains(id, " at ") { // named after the time, not delivery
+			id = ""
+		}
+	}
+	env, err := webhook.ReadDump(p)
+	if err != nil {
+		return nil, err
+	}
+	env.Event = nonil(env.Event, event)
+	env.Delivery = nonil(env.Delivery, id)
+	if env.ReceivedAt.IsZero() {
+		env.ReceivedAt = mod
+	}
+	if env.Event == "" {
+		return nil, errors.New("unable to read event type")
+	}
+	return &delivery{Envelope: env}, nil
+}
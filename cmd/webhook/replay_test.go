@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rjeczalik/gh/cmd/internal/tsc"
+	"github.com/rjeczalik/gh/webhook"
+)
+
+const secret = "dupa.8"
+
+type recorder struct {
+	mu     sync.Mutex
+	events map[string][]string // event -> delivery IDs
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	if req.Header.Get("X-Hub-Signature-256") != "sha256="+hexDigest(sha256.New, secret, body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	r.mu.Lock()
+	r.events[req.Header.Get("X-GitHub-Event")] = append(r.events[req.Header.Get("X-GitHub-Event")], req.Header.Get("X-GitHub-Delivery"))
+	r.mu.Unlock()
+}
+
+func drain(w http.ResponseWriter, req *http.Request) {
+	io.Copy(ioutil.Discard, req.Body)
+}
+
+// dump writes the body with the Dumper configured by fn and waits until
+// it's dumped.
+func dump(t *testing.T, store *webhook.MemStore, fn func(*webhook.Dumper), event, id, body string) {
+	n := len(store.Files())
+	d := webhook.DumpTo(store, http.HandlerFunc(drain))
+	fn(d)
+	req := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", id)
+	d.ServeHTTP(httptest.NewRecorder(), req)
+	for deadline := time.Now().Add(5 * time.Second); len(store.Files()) == n; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s to be dumped", id)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := webhook.NewMemStore(0)
+	plain := func(*webhook.Dumper) {}
+	dump(t, store, plain, "push", "1", `{"ref":"refs/heads/master","repository":{"full_name":"rjeczalik/gh"}}`)
+	dump(t, store, func(d *webhook.Dumper) { d.Envelope = true }, "push", "2", `{"repository":{"full_name":"octocat/gh"}}`)
+	dump(t, store, func(d *webhook.Dumper) { d.Envelope, d.Compress = true, true }, "ping", "3", `{"zen":"Design for failure."}`)
+	dump(t, store, func(d *webhook.Dumper) { d.Compress, d.Daily = true, true }, "create", "4", `{"ref":"v1.0.0"}`)
+	for _, f := range store.Files() {
+		name := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, f.Data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nd, err := webhook.NewNDJSONStore(filepath.Join(dir, "dump.ndjson"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nd.Put("issues-5.json", []byte(`{"action":"opened"}`))
+	nd.Put("issues-6.json.gz", store.Files()[2].Data)
+	nd.Close()
+	var buf bytes.Buffer
+	env := &webhook.Envelope{Event: "release", Delivery: "7", ReceivedAt: time.Now()}
+	env.SetBody([]byte(`{"action":"published"}`))
+	webhook.NewEnvelopeWriter(&buf).Write(env)
+	if err := ioutil.WriteFile(filepath.Join(dir, "envelopes.ndjson"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err := readDeliveries([]string{dir})
+	if err != nil {
+		t.Fatalf("readDeliveries()=%v", err)
+	}
+	if len(deliveries) != 7 {
+		t.Fatalf("want 7 deliveries; got %d", len(deliveries))
+	}
+	rec := &recorder{events: make(map[string][]string)}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+	r := &replayer{secret: secret, url: ts.URL, client: http.DefaultClient}
+	for _, d := range deliveries {
+		if status, err := r.send(d); err != nil || status != http.StatusOK {
+			t.Errorf("send()=%d, %v (file=%s)", status, err, d.file)
+		}
+	}
+	want := map[string]int{"push": 2, "ping": 2, "create": 1, "issues": 1, "release": 1}
+	for event, n := range want {
+		if len(rec.events[event]) != n {
+			t.Errorf("want %d %s events; got %v", n, event, rec.events[event])
+		}
+	}
+	if ids := rec.events["ping"]; len(ids) == 2 && (ids[0] != "3" || ids[1] != "3") {
+		t.Errorf("want ping deliveries to preserve X-GitHub-Delivery=3; got %v", ids)
+	}
+	cases := [...]struct {
+		r *replayer
+		n int
+	}{
+		{&replayer{events: list{"push"}}, 2},
+		{&replayer{repos: list{"rjeczalik/*"}}, 1},
+		{&replayer{events: list{"ping", "create"}}, 3},
+		{&replayer{since: timeFlag{time.Now().Add(time.Hour)}}, 0},
+		{&replayer{until: timeFlag{time.Now().Add(time.Hour)}}, 7},
+	}
+	for i, cas := range cases {
+		n := 0
+		for _, d := range deliveries {
+			if cas.r.match(d) {
+				n++
+			}
+		}
+		if n != cas.n {
+			t.Errorf("want %d matching deliveries; got %d (i=%d)", cas.n, n, i)
+		}
+	}
+}
+
+func TestReplayScript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "script.sh")
+	if err := ioutil.WriteFile(script, []byte(`{{if eq .Name "ping"}}exit 1{{end}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := tsc.New(script, nil)
+	if err != nil {
+		t.Fatalf("New()=%v", err)
+	}
+	r := &replayer{secret: secret, handler: webhook.New(secret, sc)}
+	for _, event := range []string{"push", "ping"} {
+		env := &webhook.Envelope{Event: event, Delivery: "1", ReceivedAt: time.Now()}
+		env.SetBody([]byte(`{}`))
+		status, err := r.send(&delivery{Envelope: env, file: event + "-1.json"})
+		if event == "ping" {
+			if err == nil {
+				t.Errorf("want send to fail for ping; got Status=%d", status)
+			}
+			continue
+		}
+		if err != nil || status != http.StatusNoContent {
+			t.Errorf("send()=%d, %v", status, err)
+		}
+	}
+}
+
+func TestParseDump(t *testing.T) {
+	cases := [...]struct {
+		name, event, id string
+	}{
+		{"push-ef748000-d078-11e4-91b6-77fc544482ea.json", "push", "ef748000-d078-11e4-91b6-77fc544482ea"},
+		{"2015-03-19/pull_request-1.json.gz", "pull_request", "1"},
+		{"release-2015-03-19 at 09.36.45.882.json", "release", ""},
+	}
+	for i, cas := range cases {
+		d, err := parseDump(cas.name, []byte(`{}`), time.Now())
+		if err != nil {
+			t.Errorf("parseDump()=%v (i=%d)", err, i)
+			continue
+		}
+		if d.Event != cas.event || d.Delivery != cas.id {
+			t.Errorf("want event=%s id=%s; got %s %s (i=%d)", cas.event, cas.id, d.Event, d.Delivery, i)
+		}
+	}
+	if _, err := parseDump("2015-03-19 at 09.36.45.882", []byte(`{}`), time.Now()); err == nil {
+		t.Error("want parseDump to fail for unknown event type")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+const spoolUsage = `usage: webhook spool [-json] dir
+
+Lists the deliveries waiting in the spool directory configured with the -spool
+flag, from the oldest to the latest one. Each line holds the time the delivery
+was received, its event type, delivery ID, size and entry ID. The entries, which
+could not be read, are marked as invalid.
+
+The -json flag prints the entries as a JSON array instead.`
+
+func spool(args []string) {
+	var asJSON bool
+	f := flag.NewFlagSet("spool", flag.ExitOnError)
+	f.Usage = func() {
+		fmt.Fprintln(os.Stderr, spoolUsage)
+	}
+	f.BoolVar(&asJSON, "json", false, "Prints entries as a JSON array.")
+	f.Parse(args)
+	if f.NArg() != 1 {
+		die(spoolUsage)
+	}
+	entries, invalid, err := readSpool(f.Arg(0))
+	if err != nil {
+		die(err)
+	}
+	if asJSON {
+		p, err := json.MarshalIndent(entries, "", "\t")
+		if err != nil {
+			die(err)
+		}
+		fmt.Printf("%s\n", p)
+		return
+	}
+	for _, e := range entries {
+		fmt.Printf("%s\t%s\t%s\t%d\t%s\n", e.ReceivedAt.UTC().Format(time.RFC3339), e.Event,
+			nonil(e.Delivery, "-"), e.Size, e.ID)
+	}
+	for _, id := range invalid {
+		fmt.Printf("invalid\t-\t-\t-\t%s\n", id)
+	}
+}
+
+// readSpool reads the entries of the spool directory, which may be used by
+// a running webhook command.
+func readSpool(dir string) (entries []webhook.SpoolEntry, invalid []string, err error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, fi := range fis {
+		switch name := fi.Name(); {
+		case strings.HasSuffix(name, ".json"):
+			e, err := webhook.ReadSpoolEntry(filepath.Join(dir, name))
+			switch {
+			case os.IsNotExist(err):
+				// handled in the meantime
+			case err != nil:
+				invalid = append(invalid, name)
+			default:
+				entries = append(entries, e)
+			}
+		case strings.HasSuffix(name, ".invalid"):
+			invalid = append(invalid, name)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, invalid, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+func TestReadSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := webhook.OpenSpool(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	for _, id := range []string{"1", "2"} {
+		env := &webhook.Envelope{Event: "push", Delivery: id, ReceivedAt: time.Now()}
+		env.SetBody([]byte(`{"ref":"refs/heads/master"}`))
+		if _, err := s.Put(env); err != nil {
+			t.Fatalf("Put()=%v", err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "0.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entries, invalid, err := readSpool(dir)
+	if err != nil {
+		t.Fatalf("readSpool()=%v", err)
+	}
+	if len(entries) != 2 || entries[0].Delivery != "1" || entries[1].Delivery != "2" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	if want := []string{"0.json"}; !reflect.DeepEqual(invalid, want) {
+		t.Errorf("want invalid=%v; got %v", want, invalid)
+	}
+}
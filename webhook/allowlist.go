@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Allowlist accepts requests only from the configured network ranges, e.g.
+// the ones GitHub sends the deliveries from. It is a defense in depth - the
+// signatures of the requests are verified regardless of their source.
+//
+// If the Handler is behind a reverse proxy, the proxy's ranges must be
+// configured with SetTrustedProxies. For requests, which come from a trusted
+// proxy, the client address is read from the X-Forwarded-For header.
+//
+// The ranges can be replaced at any time, e.g. after GitHub's list was
+// updated, the Allowlist is safe for concurrent use.
+type Allowlist struct {
+	mu       sync.RWMutex
+	nets     []*net.IPNet
+	proxies  []*net.IPNet
+	rejected uint64
+}
+
+// NewAllowlist creates new Allowlist for the given ranges.
+func NewAllowlist(cidrs ...string) (*Allowlist, error) {
+	a := &Allowlist{}
+	if err := a.Set(cidrs); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Set replaces the allowed ranges. Each range is either a CIDR, e.g.
+// 192.30.252.0/22, or a single IP address.
+func (a *Allowlist) Set(cidrs []string) error {
+	nets, err := parseNets(cidrs)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.nets = nets
+	a.mu.Unlock()
+	return nil
+}
+
+// SetTrustedProxies replaces the ranges of the trusted reverse proxies.
+func (a *Allowlist) SetTrustedProxies(cidrs []string) error {
+	nets, err := parseNets(cidrs)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.proxies = nets
+	a.mu.Unlock()
+	return nil
+}
+
+// LoadMeta replaces the allowed ranges with the "hooks" ones read from
+// the file, which is a copy of the response of GitHub's meta API:
+//
+//   curl -s https://api.github.com/meta > meta.json
+//
+// The extra ranges are allowed as well.
+func (a *Allowlist) LoadMeta(file string, extra ...string) error {
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	hooks, err := ParseMeta(p)
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	return a.Set(append(hooks, extra...))
+}
+
+// ParseMeta gives the "hooks" ranges of the GitHub's meta API response.
+func ParseMeta(p []byte) ([]string, error) {
+	var meta struct {
+		Hooks []string `json:"hooks"`
+	}
+	if err := json.Unmarshal(p, &meta); err != nil {
+		return nil, err
+	}
+	if len(meta.Hooks) == 0 {
+		return nil, errors.New("no hooks ranges found")
+	}
+	return meta.Hooks, nil
+}
+
+// Len gives the number of the allowed ranges.
+func (a *Allowlist) Len() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.nets)
+}
+
+// Rejected gives the number of the requests rejected so far.
+func (a *Allowlist) Rejected() uint64 {
+	return atomic.LoadUint64(&a.rejected)
+}
+
+// Allow reports whether the request comes from one of the allowed ranges
+// and counts the rejected ones. It gives the client address of the request.
+func (a *Allowlist) Allow(req *http.Request) (net.IP, bool) {
+	a.mu.RLock()
+	ip := clientIP(req, a.proxies)
+	ok := ip != nil && containsIP(a.nets, ip)
+	a.mu.RUnlock()
+	if !ok {
+		atomic.AddUint64(&a.rejected, 1)
+	}
+	return ip, ok
+}
+
+// clientIP gives the client address of the request. If the request comes
+// from a trusted proxy, the X-Forwarded-For header is read from right to
+// left and the first address, which is not a trusted proxy, is the client one.
+func clientIP(req *http.Request, proxies []*net.IPNet) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !containsIP(proxies, ip) {
+		return ip
+	}
+	var forwarded []string
+	for _, v := range req.Header["X-Forwarded-For"] {
+		forwarded = append(forwarded, strings.Split(v, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		next := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if next == nil {
+			return nil // malformed header, the client is unknown
+		}
+		ip = next
+		if !containsIP(proxies, ip) {
+			break
+		}
+	}
+	return ip
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNets(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, s := range cidrs {
+		s = strings.TrimSpace(s)
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, errors.New("invalid IP address " + s)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
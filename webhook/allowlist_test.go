@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const meta = `{
+  "verifiable_password_authentication": true,
+  "hooks": ["192.30.252.0/22", "185.199.108.0/22", "140.82.112.0/20", "2a0a:a440::/29"],
+  "web": ["192.30.252.0/22"]
+}`
+
+func TestAllowlist(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "meta.json")
+	if err := ioutil.WriteFile(file, []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+	a := &Allowlist{}
+	if err := a.LoadMeta(file, "127.0.0.1"); err != nil {
+		t.Fatalf("LoadMeta()=%v", err)
+	}
+	if err := a.SetTrustedProxies([]string{"10.0.0.0/8"}); err != nil {
+		t.Fatalf("SetTrustedProxies()=%v", err)
+	}
+	cases := [...]struct {
+		remote    string
+		forwarded []string
+		ip        string
+		ok        bool
+	}{
+		{"192.30.252.34:41235", nil, "192.30.252.34", true},
+		{"[2a0a:a440::1]:41235", nil, "2a0a:a440::1", true},
+		{"127.0.0.1:8080", nil, "127.0.0.1", true},
+		{"127.0.0.2:8080", nil, "127.0.0.2", false},
+		{"8.8.8.8:41235", nil, "8.8.8.8", false},
+		// X-Forwarded-For is ignored for untrusted peers.
+		{"8.8.8.8:41235", []string{"192.30.252.34"}, "8.8.8.8", false},
+		{"10.0.0.1:41235", []string{"192.30.252.34"}, "192.30.252.34", true},
+		{"10.0.0.1:41235", []string{"8.8.8.8, 192.30.252.34, 10.0.0.2"}, "192.30.252.34", true},
+		{"10.0.0.1:41235", []string{"192.30.252.34", "8.8.8.8"}, "8.8.8.8", false},
+		{"10.0.0.1:41235", []string{"garbage"}, "<nil>", false},
+		{"10.0.0.1:41235", nil, "10.0.0.1", false},
+	}
+	for i, cas := range cases {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.RemoteAddr = cas.remote
+		for _, v := range cas.forwarded {
+			req.Header.Add("X-Forwarded-For", v)
+		}
+		ip, ok := a.Allow(req)
+		if ip.String() != cas.ip || ok != cas.ok {
+			t.Errorf("want ip=%s ok=%t; got %v %t (i=%d)", cas.ip, cas.ok, ip, ok, i)
+		}
+	}
+	if n := a.Rejected(); n != 6 {
+		t.Errorf("want Rejected()=6; got %d", n)
+	}
+	if err := a.Set([]string{"192.30.252.0/33"}); err == nil {
+		t.Error("want Set to fail for invalid range")
+	}
+	if n := a.Len(); n != 5 {
+		t.Errorf("want ranges to be kept after failed Set; got %d", n)
+	}
+	if _, err := ParseMeta([]byte(`{"web":["192.30.252.0/22"]}`)); err == nil {
+		t.Error("want ParseMeta to fail for missing hooks")
+	}
+}
+
+func TestHandlerAllowlist(t *testing.T) {
+	a, err := NewAllowlist("192.30.252.0/22")
+	if err != nil {
+		t.Fatalf("NewAllowlist()=%v", err)
+	}
+	h := New(secret, BlanketHandler{})
+	h.Allowlist = a
+	body := []byte(`{"zen":"Design for failure."}`)
+	for _, remote := range []string{"8.8.8.8:41235", "192.30.252.34:41235"} {
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		req.RemoteAddr = remote
+		req.Header.Set("X-GitHub-Event", "ping")
+		req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		h.Wait()
+		if remote == "8.8.8.8:41235" && w.Code != http.StatusForbidden {
+			t.Errorf("want Code=403; got %d", w.Code)
+		}
+		if remote != "8.8.8.8:41235" && w.Code == http.StatusForbidden {
+			t.Errorf("want request from %s to be allowed", remote)
+		}
+	}
+	if n := a.Rejected(); n != 1 {
+		t.Errorf("want Rejected()=1; got %d", n)
+	}
+}
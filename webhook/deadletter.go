@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DeadLetters is a directory of deliveries, which failed to be handled
+// after all the attempts. Each delivery is written as an Envelope to
+// a separate file, together with the number of attempts and the error of
+// the last one. The deliveries can be listed and handled again later,
+// e.g. with the Handler's Dispatch method.
+type DeadLetters struct {
+	Dir string // directory where deliveries are written
+}
+
+// OpenDeadLetters opens the dead letters directory, creating it if needed.
+func OpenDeadLetters(dir string) (*DeadLetters, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DeadLetters{Dir: dir}, nil
+}
+
+// Put writes the delivery and gives its ID.
+func (d *DeadLetters) Put(env *Envelope) (string, error) {
+	var buf bytes.Buffer
+	if err := WriteEnvelope(&buf, env); err != nil {
+		return "", err
+	}
+	id := newEntryID()
+	if err := writeEntry(d.Dir, id, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// List gives the deliveries, from the oldest to the latest one.
+func (d *DeadLetters) List() ([]SpoolEntry, error) {
+	fis, err := ioutil.ReadDir(d.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var entries []SpoolEntry
+	for _, fi := range fis {
+		if !strings.HasSuffix(fi.Name(), ".json") || !fi.Mode().IsRegular() {
+			continue
+		}
+		e, err := ReadSpoolEntry(filepath.Join(d.Dir, fi.Name()))
+		if os.IsNotExist(err) {
+			continue // removed in the meantime
+		}
+		if err != nil {
+			return nil, errors.New(fi.Name() + ": " + err.Error())
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+// Get reads the delivery of the given ID.
+func (d *DeadLetters) Get(id string) (*Envelope, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	p, err := ioutil.ReadFile(filepath.Join(d.Dir, id))
+	if err != nil {
+		return nil, err
+	}
+	return ReadEnvelope(p)
+}
+
+// Remove removes the delivery of the given ID, e.g. after it was handled.
+func (d *DeadLetters) Remove(id string) error {
+	if err := validID(id); err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(d.Dir, id))
+}
+
+func validID(id string) error {
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return errors.New("invalid ID " + id)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"reflect"
+	"strings"
+)
+
+// PullRequestMergedEvent is derived from the pull_request event, which was
+// triggered by merging a pull request.
+type PullRequestMergedEvent struct {
+	*PullRequestEvent
+}
+
+// EventName implements the Event interface.
+func (*PullRequestMergedEvent) EventName() string { return "pull_request_merged" }
+
+// BranchCreatedEvent is derived from the create event, which was triggered
+// by creating a branch.
+type BranchCreatedEvent struct {
+	*CreateEvent
+}
+
+// EventName implements the Event interface.
+func (*BranchCreatedEvent) EventName() string { return "branch_created" }
+
+// TagPushedEvent is derived from the push event, which was triggered by
+// pushing a tag.
+type TagPushedEvent struct {
+	*PushEvent
+}
+
+// EventName implements the Event interface.
+func (*TagPushedEvent) EventName() string { return "tag_pushed" }
+
+// ReleasePublishedEvent is derived from the release event, which was triggered
+// by publishing a release.
+type ReleasePublishedEvent struct {
+	*ReleaseEvent
+}
+
+// EventName implements the Event interface.
+func (*ReleasePublishedEvent) EventName() string { return "release_published" }
+
+// ReviewApprovedEvent is derived from the pull_request_review event, which was
+// triggered by submitting an approving review.
+type ReviewApprovedEvent struct {
+	*PullRequestReviewEvent
+}
+
+// EventName implements the Event interface.
+func (*ReviewApprovedEvent) EventName() string { return "review_approved" }
+
+var derived = payloadsMap{
+	"branch_created":      reflect.TypeOf(BranchCreatedEvent{}),
+	"pull_request_merged": reflect.TypeOf(PullRequestMergedEvent{}),
+	"release_published":   reflect.TypeOf(ReleasePublishedEvent{}),
+	"review_approved":     reflect.TypeOf(ReviewApprovedEvent{}),
+	"tag_pushed":          reflect.TypeOf(TagPushedEvent{}),
+}
+
+// eventName gives the name of either delivered or derived event of the
+// given type.
+func eventName(typ reflect.Type) (string, bool) {
+	if name, ok := payloads.Name(typ); ok {
+		return name, true
+	}
+	return derived.Name(typ)
+}
+
+// Derive gives the higher-level events, which are derived from the given
+// delivered event payload. It returns nil if there's no event, which could
+// be derived from the payload.
+func Derive(payload interface{}) []Event {
+	switch e := payload.(type) {
+	case *PullRequestEvent:
+		if e.Action == "closed" && e.PullRequest.Merged {
+			return []Event{&PullRequestMergedEvent{e}}
+		}
+	case *CreateEvent:
+		if e.IsBranchCreation() {
+			return []Event{&BranchCreatedEvent{e}}
+		}
+	case *PushEvent:
+		if e.IsTag() && !e.Deleted {
+			return []Event{&TagPushedEvent{e}}
+		}
+	case *ReleaseEvent:
+		if e.Action == "published" {
+			return []Event{&ReleasePublishedEvent{e}}
+		}
+	case *PullRequestReviewEvent:
+		if e.Action == "submitted" && strings.EqualFold(e.Review.State, "approved") {
+			return []Event{&ReviewApprovedEvent{e}}
+		}
+	}
+	return nil
+}
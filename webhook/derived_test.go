@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestDerive(t *testing.T) {
+	cases := [...]struct {
+		payload interface{}
+		events  []string
+	}{
+		{&PullRequestEvent{Action: "closed", PullRequest: PullRequest{Merged: true}}, []string{"pull_request_merged"}},
+		{&PullRequestEvent{Action: "closed"}, nil},
+		{&PullRequestEvent{Action: "opened"}, nil},
+		{&CreateEvent{Ref: "feature", RefType: "branch"}, []string{"branch_created"}},
+		{&CreateEvent{Ref: "v1.0.0", RefType: "tag"}, nil},
+		{&PushEvent{Ref: "refs/tags/v1.0.0", Created: true}, []string{"tag_pushed"}},
+		{&PushEvent{Ref: "refs/tags/v1.0.0", Deleted: true}, nil},
+		{&PushEvent{Ref: "refs/heads/master"}, nil},
+		{&ReleaseEvent{Action: "published"}, []string{"release_published"}},
+		{&ReleaseEvent{Action: "created"}, nil},
+		{&PullRequestReviewEvent{Action: "submitted", Review: Review{State: "approved"}}, []string{"review_approved"}},
+		{&PullRequestReviewEvent{Action: "submitted", Review: Review{State: "commented"}}, nil},
+		{&PingEvent{}, nil},
+	}
+	for i, cas := range cases {
+		var events []string
+		for _, e := range Derive(cas.payload) {
+			events = append(events, e.EventName())
+			if typ, ok := derived.Type(e.EventName()); !ok || reflect.TypeOf(e).Elem() != typ {
+				t.Errorf("want %T to be registered as %s (i=%d)", e, e.EventName(), i)
+			}
+		}
+		if !reflect.DeepEqual(events, cas.events) {
+			t.Errorf("want events=%v; got %v (i=%d)", cas.events, events, i)
+		}
+	}
+}
+
+func TestDeriveFromFile(t *testing.T) {
+	var e PullRequestReviewEvent
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "pull_request_review.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	events := Derive(&e)
+	if len(events) != 1 {
+		t.Fatalf("want 1 derived event; got %d", len(events))
+	}
+	approved, ok := events[0].(*ReviewApprovedEvent)
+	if !ok {
+		t.Fatalf("want *ReviewApprovedEvent; got %T", events[0])
+	}
+	if approved.Review.User.Login != "baxterthehacker" || approved.GetRepository() == nil {
+		t.Errorf("want derived event to carry the delivered one; got %+v", approved.PullRequestReviewEvent)
+	}
+}
+
+type DerivedHandler map[string]int
+
+func (dh DerivedHandler) All(event string, _ interface{})                { dh["*"]++ }
+func (dh DerivedHandler) Merged(e *PullRequestMergedEvent)               { dh[e.EventName()]++ }
+func (dh DerivedHandler) Approved(context.Context, *ReviewApprovedEvent) { dh["review_approved"]++ }
+func (dh DerivedHandler) Tag(e *TagPushedEvent)                          { dh[e.EventName()]++ }
+func (dh DerivedHandler) PullRequestReview(context.Context, *PullRequestReviewEvent) {
+	dh["pull_request_review"]++
+}
+
+func TestHandlerDerived(t *testing.T) {
+	dh := DerivedHandler{}
+	m := payloadMethods(reflect.TypeOf(dh))
+	events := make([]string, 0, len(m))
+	for k := range m {
+		events = append(events, k)
+	}
+	sort.Strings(events)
+	want := []string{"*", "pull_request_merged", "pull_request_review", "review_approved", "tag_pushed"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("want events=%v; got %v", want, events)
+	}
+	h := New(secret, dh)
+	cases := [...]struct {
+		event   string
+		payload interface{}
+		counts  map[string]int
+	}{{
+		"pull_request",
+		&PullRequestEvent{Action: "closed", PullRequest: PullRequest{Merged: true}},
+		map[string]int{"*": 1, "pull_request_merged": 1},
+	}, {
+		"pull_request_review",
+		&PullRequestReviewEvent{Action: "submitted", Review: Review{State: "approved"}},
+		map[string]int{"pull_request_review": 1, "review_approved": 1},
+	}, {
+		"release",
+		&ReleaseEvent{Action: "published"},
+		map[string]int{"*": 1},
+	}}
+	for i, cas := range cases {
+		for k := range dh {
+			delete(dh, k)
+		}
+		req, err := http.NewRequest("POST", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		h.handle(cas.event, cas.payload, rec, req)
+		if !reflect.DeepEqual(map[string]int(dh), cas.counts) {
+			t.Errorf("want counts=%v; got %v (i=%d)", cas.counts, dh, i)
+		}
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("want Code=204; got %d (i=%d)", rec.Code, i)
+		}
+	}
+}
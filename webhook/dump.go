@@ -2,13 +2,18 @@ package webhook
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
@@ -26,24 +31,94 @@ func nonil(err ...error) error {
 }
 
 // Dumper is a helper handler, which wraps other http.Handler and dumps its
-// requests' bodies to files in Dir directory and named after <event>-<delivery>.json,
-// where:
+// requests' bodies to files in Dir directory, or to the Store, and named after
+// <event>-<delivery>.json, where:
 //
 //   - <event> is value of X-GitHub-Event header
 //   - <delivery> is value of X-GitHub-Delivery header
 //
 // If headers are missing, current time is used instead.
+//
+// If Envelope is true, each file holds an Envelope, which wraps the request's
+// body together with the details of the delivery. The status is the one, which
+// the handler responded with - for a *Handler it is written after the event
+// was handled, see Failed. Both cmd/structgen and go generate read the payloads
+// from the envelopes, so the dumped files can be used as testdata in either
+// mode.
+//
+// By default every request is dumped. The Failed, Events, Repos and Sample
+// fields limit which of the requests are dumped - a request is dumped only
+// if it passes all of the configured filters.
+//
+// If any of the MaxFiles, MaxBytes or MaxAge limits is set, the first request
+// starts a background janitor, which periodically removes the oldest files.
+// The Close method stops the janitor and closes the Store.
 type Dumper struct {
-	Handler http.Handler // underlying handler
-	Dir     string       // directory where files are written
+	Handler  http.Handler // underlying handler
+	Dir      string       // directory where files are written
+	Envelope bool         // whether to dump the request details along with the body
+
+	// Failed makes the Dumper dump only requests, which the handler responded
+	// to with a status code of 400 or greater. For a *Handler, the status is
+	// the one written after the event was handled, thus the deliveries, which
+	// the service failed to handle, are dumped as well, even though the client
+	// already received 200 OK. The deliveries, which were spooled or delayed
+	// by the rate limits, have status 202.
+	Failed bool
+
+	// Events, if non-empty, lists the event types, which are dumped.
+	Events []string
+
+	// Repos, if non-empty, lists the repositories, which are dumped. Each
+	// element is matched against the full name of the payload's repository
+	// with the path.Match function, e.g. "rjeczalik/*".
+	Repos []string
+
+	// Sample is the probability of dumping a request, which passed the other
+	// filters. If it is 0 or greater than 1, all the requests are dumped.
+	Sample float64
+
+	// Redactor, if non-nil, rewrites sensitive values of the payloads before
+	// they are dumped. Payloads, which are not valid JSON, are not dumped.
+	Redactor *Redactor
+
+	// Compress makes the Dumper gzip the files, their names get additional
+	// .gz extension.
+	Compress bool
+
+	// Daily makes the Dumper write the files into subdirectories of Dir,
+	// which are named after the day the request was received, e.g. 2015-03-19.
+	Daily bool
+
+	// MaxFiles, MaxBytes and MaxAge configure retention of the files dumped
+	// to Dir, see Clean for details. Zero value means no limit. The limits
+	// are ignored, if Store is set.
+	MaxFiles int
+	MaxBytes int64
+	MaxAge   time.Duration
+
+	// CleanInterval specifies how often the retention is enforced.
+	// If zero, DefaultCleanInterval is used instead.
+	CleanInterval time.Duration
 
 	// ErrorLog specifies an optional logger for errors serving requests.
 	// If nil, logging goes to os.Stderr via the log package's standard logger.
 	ErrorLog *log.Logger
 
+	// Store specifies an optional storage for the dumped requests.
+	// If nil, the files are written to Dir.
+	Store DumpStore
+
 	// WriteFile specifies an optional file writer.
 	// If nil, ioutil.WriteFile is used instead.
+	//
+	// Deprecated: Use Store with a DirStore instead.
 	WriteFile func(string, []byte, os.FileMode) error
+
+	random func() float64 // used for sampling, rand.Float64 if nil
+
+	mu   sync.Mutex    // protects stop
+	stop chan struct{} // closed to stop the janitor, nil if not started
 }
 
 // Dump creates new Dumper handler, which wraps a webhook handler and dumps each
@@ -84,31 +159,220 @@ func Dump(dir string, handler http.Handler) *Dumper {
 	return d
 }
 
+// DumpTo creates new Dumper handler, which wraps the handler and dumps each
+// request's body to the store.
+//
+// If handler is a *webhook Handler and its ErrorLog field is non-nil, DumpTo
+// uses it for logging.
+func DumpTo(store DumpStore, handler http.Handler) *Dumper {
+	d := &Dumper{
+		Handler: handler,
+		Store:   store,
+	}
+	if handler, ok := handler.(*Handler); ok {
+		d.ErrorLog = handler.ErrorLog
+	}
+	return d
+}
+
+// statusWriter records the status of the response. After the handler's
+// ServeHTTP returned, the writes are only recorded, as the response was
+// already finished.
+type statusWriter struct {
+	http.ResponseWriter
+	mu       sync.Mutex
+	status   int
+	returned bool           // whether the handler's ServeHTTP returned
+	header   http.Header    // headers set after ServeHTTP returned
+	wg       sync.WaitGroup // tracks the asynchronous dispatch
+}
+
+func (w *statusWriter) dispatching() func() {
+	w.wg.Add(1)
+	return w.wg.Done
+}
+
+// finish marks the handler's ServeHTTP as returned.
+func (w *statusWriter) finish() {
+	w.mu.Lock()
+	w.returned = true
+	w.mu.Unlock()
+}
+
+func (w *statusWriter) Header() http.Header {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.returned {
+		if w.header == nil {
+			w.header = make(http.Header)
+		}
+		return w.header
+	}
+	return w.ResponseWriter.Header()
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.status == 0 {
+		w.status = status
+	}
+	if !w.returned {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.status = defaultStatus(w.status)
+	if w.returned {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *statusWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && !w.returned {
+		flusher.Flush()
+	}
+}
+
+// Status gives the status of the response, which was written. The server
+// responds with 200, if no status was written.
+func (w *statusWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return defaultStatus(w.status)
+}
+
 // ServeHTTP implements the http.Handler interface.
 func (d *Dumper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	received := time.Now()
+	d.startJanitor()
+	event, delivery := req.Header.Get("X-GitHub-Event"), req.Header.Get("X-GitHub-Delivery")
+	if !d.matchEvent(event) {
+		d.Handler.ServeHTTP(w, req)
+		return
+	}
 	buf := &bytes.Buffer{}
 	req.Body = ioutil.NopCloser(io.TeeReader(req.Body, buf))
-	d.Handler.ServeHTTP(w, req)
-	go d.dump(req.Header.Get("X-GitHub-Event"), req.Header.Get("X-GitHub-Delivery"), buf)
+	sw := &statusWriter{ResponseWriter: w}
+	d.Handler.ServeHTTP(sw, req)
+	sw.finish()
+	var env *Envelope
+	if d.Envelope {
+		env = NewEnvelope(req, buf.Bytes())
+		env.ReceivedAt = received.UTC()
+	}
+	addr := req.RemoteAddr
+	go func() {
+		// The *Handler responds after the event was handled, which happens
+		// after its ServeHTTP returned.
+		sw.wg.Wait()
+		status := sw.Status()
+		body := buf.Bytes()
+		if !d.match(status, body) {
+			return
+		}
+		if env != nil {
+			env.Status = status
+		}
+		if d.Redactor != nil {
+			p, err := d.Redactor.Redact(body)
+			if err != nil {
+				d.logf("ERROR %s: error redacting payload: %v", addr, err)
+				return
+			}
+			body = p
+		}
+		if env == nil {
+			d.dump(event, delivery, received, body)
+			return
+		}
+		env.SetBody(body)
+		var buf bytes.Buffer
+		if err := WriteEnvelope(&buf, env); err != nil {
+			d.logf("ERROR %s: error encoding envelope: %v", addr, err)
+			return
+		}
+		d.dump(event, delivery, received, buf.Bytes())
+	}()
+}
+
+// matchEvent reports whether requests for the event are dumped.
+func (d *Dumper) matchEvent(event string) bool {
+	if len(d.Events) == 0 {
+		return true
+	}
+	for _, e := range d.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether the handled request, which body is given, passes
+// the Failed, Repos and Sample filters.
+func (d *Dumper) match(status int, body []byte) bool {
+	if d.Failed && status < 400 {
+		return false
+	}
+	if len(d.Repos) != 0 && !d.matchRepo(body) {
+		return false
+	}
+	if d.Sample > 0 && d.Sample < 1 {
+		random := d.random
+		if random == nil {
+			random = rand.Float64
+		}
+		return random() < d.Sample
+	}
+	return true
+}
+
+func (d *Dumper) matchRepo(body []byte) bool {
+	var v struct {
+		Repository *struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil || v.Repository == nil {
+		return false
+	}
+	for _, pattern := range d.Repos {
+		if ok, _ := path.Match(pattern, v.Repository.FullName); ok {
+			return true
+		}
+	}
+	return false
 }
 
-func (d *Dumper) dump(event, delivery string, buf *bytes.Buffer) {
+func (d *Dumper) dump(event, delivery string, received time.Time, p []byte) {
 	var name string
 	switch {
 	case event != "" && delivery != "":
-		name = filepath.Join(d.Dir, fmt.Sprintf("%s-%s.json", event, delivery))
+		name = fmt.Sprintf("%s-%s.json", event, delivery)
 	case event != "":
-		name = filepath.Join(d.Dir, fmt.Sprintf("%s-%s.json", event, now()))
+		name = fmt.Sprintf("%s-%s.json", event, now())
 	default:
-		name = filepath.Join(d.Dir, now())
+		name = now()
 	}
-	var err error
-	if d.WriteFile != nil {
-		err = d.WriteFile(name, buf.Bytes(), 0644)
-	} else {
-		err = ioutil.WriteFile(name, buf.Bytes(), 0644)
+	if d.Daily {
+		name = path.Join(received.UTC().Format("2006-01-02"), name)
+	}
+	if d.Compress {
+		var err error
+		if p, err = gzipBytes(p); err != nil {
+			d.logf("ERROR %q: error compressing file: %v", name, err)
+			return
+		}
+		name += ".gz"
 	}
-	switch err {
+	switch err := d.store().Put(name, p); err {
 	case nil:
 		d.logf("INFO %q: written file", name)
 	default:
@@ -116,6 +380,25 @@ func (d *Dumper) dump(event, delivery string, buf *bytes.Buffer) {
 	}
 }
 
+func (d *Dumper) store() DumpStore {
+	if d.Store != nil {
+		return d.Store
+	}
+	return &DirStore{Dir: d.Dir, WriteFile: d.WriteFile}
+}
+
+func gzipBytes(p []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(p); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (d *Dumper) logf(format string, args ...interface{}) {
 	if d.ErrorLog != nil {
 		d.ErrorLog.Printf(format, args...)
@@ -3,11 +3,17 @@ package webhook
 import (
 	"bytes"
 	"crypto/sha1"
+	"encoding/json"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func hash(r io.Reader) ([]byte, error) {
@@ -58,3 +64,200 @@ func TestDump(t *testing.T) {
 	}
 	testHandler(t, h)
 }
+
+func TestDumpEnvelope(t *testing.T) {
+	var mu sync.Mutex
+	envs := make(map[string]*Envelope)
+	test := func(name string, p []byte, _ os.FileMode) error {
+		env, err := ReadEnvelope(p)
+		if err != nil {
+			t.Errorf("ReadEnvelope()=%v (name=%s)", err, name)
+			return nil
+		}
+		mu.Lock()
+		envs[env.Event] = env
+		mu.Unlock()
+		return nil
+	}
+	h := &Dumper{
+		Handler:   New(secret, BlanketHandler{}),
+		Envelope:  true,
+		WriteFile: test,
+	}
+	start := time.Now().Add(-time.Second)
+	testHandler(t, h)
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		mu.Lock()
+		n := len(envs)
+		mu.Unlock()
+		if n == len(payloads) {
+			break
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for event := range payloads {
+		env, ok := envs[event]
+		if !ok {
+			t.Errorf("no envelope written for the %s event", event)
+			continue
+		}
+		body, err := ioutil.ReadFile(filepath.Join("testdata", event+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want, got bytes.Buffer
+		if err := nonil(json.Compact(&want, body), json.Compact(&got, env.Body)); err != nil {
+			t.Fatalf("Compact()=%v (event=%s)", err, event)
+		}
+		if !bytes.Equal(want.Bytes(), got.Bytes()) {
+			t.Errorf("envelope body differs from testdata/%s.json", event)
+		}
+		if sig := env.Headers.Get("X-Hub-Signature"); sig != "sha1="+hmacHexDigest(secret, body) {
+			t.Errorf("want X-Hub-Signature to be dumped; got %q (event=%s)", sig, event)
+		}
+		if env.RemoteAddr == "" || env.ReceivedAt.Before(start) || env.Status != http.StatusNoContent {
+			t.Errorf("want remote address, received time and status; got %q, %v, %d (event=%s)",
+				env.RemoteAddr, env.ReceivedAt, env.Status, event)
+		}
+	}
+}
+
+func TestEnvelopeInvalidBody(t *testing.T) {
+	req, err := http.NewRequest("POST", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := NewEnvelope(req, []byte("payload=%7B%7D"))
+	p, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal()=%v", err)
+	}
+	var v struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(p, &v); err != nil {
+		t.Fatalf("Unmarshal()=%v", err)
+	}
+	if v.Body != "payload=%7B%7D" {
+		t.Errorf("want body=payload=%%7B%%7D; got %q", v.Body)
+	}
+	if p := env.Payload(); string(p) != "payload=%7B%7D" {
+		t.Errorf("want payload=payload=%%7B%%7D; got %q", p)
+	}
+}
+
+func TestDumpMatch(t *testing.T) {
+	push := []byte(`{"ref":"refs/heads/master","repository":{"full_name":"rjeczalik/gh"}}`)
+	fork := []byte(`{"repository":{"full_name":"octocat/gh"}}`)
+	zen := []byte(`{"zen":"Design for failure."}`)
+	half := func() float64 { return 0.5 }
+	cases := [...]struct {
+		d      *Dumper
+		event  string
+		status int
+		body   []byte
+		ok     bool
+	}{
+		{&Dumper{}, "push", 200, push, true},
+		{&Dumper{Failed: true}, "push", 200, push, false},
+		{&Dumper{Failed: true}, "push", 401, push, true},
+		{&Dumper{Failed: true}, "push", 500, push, true},
+		{&Dumper{Events: []string{"push", "create"}}, "push", 200, push, true},
+		{&Dumper{Events: []string{"push", "create"}}, "ping", 200, zen, false},
+		{&Dumper{Repos: []string{"rjeczalik/gh"}}, "push", 200, push, true},
+		{&Dumper{Repos: []string{"rjeczalik/*"}}, "push", 200, fork, false},
+		{&Dumper{Repos: []string{"rjeczalik/*", "octocat/*"}}, "push", 200, fork, true},
+		{&Dumper{Repos: []string{"*/*"}}, "ping", 200, zen, false},
+		{&Dumper{Repos: []string{"*/*"}}, "push", 400, []byte("payload=%7B%7D"), false},
+		{&Dumper{Sample: 0.4, random: half}, "push", 200, push, false},
+		{&Dumper{Sample: 0.6, random: half}, "push", 200, push, true},
+		{&Dumper{Sample: 1.5, random: half}, "push", 200, push, true},
+		{&Dumper{Failed: true, Sample: 0.6, random: half}, "push", 200, push, false},
+	}
+	for i, cas := range cases {
+		if ok := cas.d.matchEvent(cas.event) && cas.d.match(cas.status, cas.body); ok != cas.ok {
+			t.Errorf("want match=%t; got %t (i=%d)", cas.ok, ok, i)
+		}
+	}
+}
+
+func TestDumpFailed(t *testing.T) {
+	written := make(chan string, 2)
+	h := &Dumper{
+		Handler: New(secret, BlanketHandler{}),
+		Failed:  true,
+		WriteFile: func(name string, _ []byte, _ os.FileMode) error {
+			written <- filepath.Base(name)
+			return nil
+		},
+	}
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+	body := []byte(`{"zen":"Design for failure."}`)
+	for _, sig := range []string{hmacHexDigest(secret, body), "invalid"} {
+		req, err := http.NewRequest("POST", ts.URL, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-GitHub-Event", "ping")
+		req.Header.Set("X-GitHub-Delivery", sig)
+		req.Header.Set("X-Hub-Signature", "sha1="+sig)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Do(req)=%v", err)
+		}
+		resp.Body.Close()
+	}
+	select {
+	case name := <-written:
+		if name != "ping-invalid.json" {
+			t.Errorf("want ping-invalid.json to be dumped; got %s", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the failed request to be dumped")
+	}
+	select {
+	case name := <-written:
+		t.Errorf("want only failed requests to be dumped; got %s", name)
+	default:
+	}
+}
+
+func TestDumpFailedHandling(t *testing.T) {
+	written := make(chan string, 2)
+	h := &Dumper{
+		Handler: New(secret, &FlakyHandler{n: 2}),
+		Failed:  true,
+		WriteFile: func(name string, _ []byte, _ os.FileMode) error {
+			written <- filepath.Base(name)
+			return nil
+		},
+	}
+	// The first delivery fails to be handled, the second one succeeds.
+	for _, id := range []string{"1", "2"} {
+		body := []byte(`{"ref":"refs/heads/master"}`)
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-GitHub-Delivery", id)
+		req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, body))
+		req.Header.Set("Content-Type", "application/json")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		h.Handler.(*Handler).Wait()
+	}
+	select {
+	case name := <-written:
+		if name != "push-1.json" {
+			t.Errorf("want push-1.json to be dumped; got %s", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the failed delivery to be dumped")
+	}
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case name := <-written:
+		t.Errorf("want only failed deliveries to be dumped; got %s", name)
+	default:
+	}
+}
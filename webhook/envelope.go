@@ -0,0 +1,263 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvelopeVersion is the version of the Envelope format, which is written
+// by this package.
+const EnvelopeVersion = 1
+
+// ErrNotEnvelope is returned by ReadEnvelope when the JSON value is not
+// an envelope, e.g. when it's a bare request body.
+var ErrNotEnvelope = errors.New("not an envelope")
+
+// Envelope is a single delivery of a webhook - the request body together with
+// its headers and other details. It is the wire format shared by the Dumper,
+// the replay command and other features, which persist or transport
+// the deliveries. Encoded as JSON it looks like:
+//
+//   {
+//     "version": 1,
+//     "event": "push",
+//     "delivery": "ef748000-d078-11e4-91b6-77fc544482ea",
+//     "headers": {"X-GitHub-Event": ["push"], "X-Hub-Signature": ["sha1=..."], ...},
+//     "body": {"ref": "refs/heads/master", ...},
+//     "remote_addr": "192.30.252.34:41235",
+//     "received_at": "2015-03-19T09:36:45.882Z",
+//     "status": 200
+//   }
+//
+// The body is stored as a JSON string, if it's not a valid JSON value, e.g.
+// for a form-encoded request. The attempts and error members are set only for
+// the deliveries, which failed to be handled, see DeadLetters. The envelopes
+// written before the format was versioned have no version, event and delivery
+// members - ReadEnvelope reads them as well, taking the event and delivery from
+// the headers.
+type Envelope struct {
+	Version    int             `json:"version"`               // version of the format
+	Event      string          `json:"event"`                 // value of X-GitHub-Event header
+	Delivery   string          `json:"delivery"`              // value of X-GitHub-Delivery header
+	Headers    http.Header     `json:"headers"`               // request headers
+	Body       json.RawMessage `json:"body"`                  // request body
+	RemoteAddr string          `json:"remote_addr,omitempty"` // network address of the sender
+	ReceivedAt time.Time       `json:"received_at"`           // time the request was received
+	Status     int             `json:"status,omitempty"`      // status of the response, if known
+	Attempts   int             `json:"attempts,omitempty"`    // number of failed attempts of handling
+	Error      string          `json:"error,omitempty"`       // error of the last attempt
+}
+
+// NewEnvelope wraps the request and its body, which was already read.
+// The received time is set to current time.
+func NewEnvelope(req *http.Request, body []byte) *Envelope {
+	env := &Envelope{
+		Version:    EnvelopeVersion,
+		Event:      req.Header.Get("X-GitHub-Event"),
+		Delivery:   req.Header.Get("X-GitHub-Delivery"),
+		Headers:    make(http.Header, len(req.Header)),
+		RemoteAddr: req.RemoteAddr,
+		ReceivedAt: time.Now().UTC(),
+	}
+	for k, v := range req.Header {
+		env.Headers[k] = append([]string(nil), v...)
+	}
+	env.SetBody(body)
+	return env
+}
+
+// SetBody sets the request body. The body is stored as a JSON string, if it's not
+// a valid JSON value.
+func (e *Envelope) SetBody(body []byte) {
+	if json.Valid(body) {
+		e.Body = body
+	} else {
+		e.Body, _ = json.Marshal(string(body))
+	}
+}
+
+// Payload gives the request body. The body, which was stored as a JSON
+// string, is unquoted.
+func (e *Envelope) Payload() []byte {
+	if len(e.Body) != 0 && e.Body[0] == '"' {
+		var s string
+		if json.Unmarshal(e.Body, &s) == nil {
+			return []byte(s)
+		}
+	}
+	return e.Body
+}
+
+// Request creates new POST request, which redelivers the envelope to the given
+// url. The request has the original headers, apart from the Content-Length
+// one, thus the signatures need to be updated if the body was modified or it
+// is sent with a different secret.
+func (e *Envelope) Request(url string) (*http.Request, error) {
+	body := e.Payload()
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range e.Headers {
+		if http.CanonicalHeaderKey(k) != "Content-Length" {
+			req.Header[k] = append([]string(nil), v...)
+		}
+	}
+	if e.Event != "" {
+		req.Header.Set("X-GitHub-Event", e.Event)
+	}
+	if e.Delivery != "" {
+		req.Header.Set("X-GitHub-Delivery", e.Delivery)
+	}
+	return req, nil
+}
+
+// ReadEnvelope decodes the envelope from the JSON value. It returns
+// ErrNotEnvelope, if the value is not an envelope.
+func ReadEnvelope(p []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(p, &env); err != nil {
+		if json.Valid(p) {
+			return nil, ErrNotEnvelope
+		}
+		return nil, err
+	}
+	if len(env.Body) == 0 || env.Version == 0 && env.Headers == nil {
+		return nil, ErrNotEnvelope
+	}
+	if env.Version > EnvelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version %d", env.Version)
+	}
+	if env.Event == "" {
+		env.Event = env.Headers.Get("X-GitHub-Event")
+	}
+	if env.Delivery == "" {
+		env.Delivery = env.Headers.Get("X-GitHub-Delivery")
+	}
+	env.Version = EnvelopeVersion
+	return &env, nil
+}
+
+// ReadDump decodes the envelope from the content of a dumped file, which is
+// either a request body or an envelope written by the Dumper. The content is
+// decompressed first, if it was gzipped. The request body is wrapped in
+// an envelope, which has only the version and body set.
+func ReadDump(p []byte) (*Envelope, error) {
+	if len(p) > 2 && p[0] == 0x1f && p[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(p))
+		if err != nil {
+			return nil, err
+		}
+		if p, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	switch env, err := ReadEnvelope(p); err {
+	case nil:
+		return env, nil
+	case ErrNotEnvelope:
+		env = &Envelope{Version: EnvelopeVersion}
+		env.SetBody(p)
+		return env, nil
+	default:
+		return nil, err
+	}
+}
+
+// ReadPayload gives the request body from the content of a dumped file,
+// see ReadDump.
+func ReadPayload(p []byte) ([]byte, error) {
+	env, err := ReadDump(p)
+	if err != nil {
+		return nil, err
+	}
+	return env.Payload(), nil
+}
+
+// WriteEnvelope writes the envelope as a single, indented JSON value, which is
+// the format of the files written by the Dumper.
+func WriteEnvelope(w io.Writer, env *Envelope) error {
+	p, err := json.MarshalIndent(env.versioned(), "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(p, '\n'))
+	return err
+}
+
+func (e *Envelope) versioned() *Envelope {
+	if e.Version != 0 {
+		return e
+	}
+	env := *e
+	env.Version = EnvelopeVersion
+	return &env
+}
+
+// EnvelopeWriter writes a stream of envelopes, one compact JSON value per line
+// (NDJSON). Write can be called concurrently.
+type EnvelopeWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewEnvelopeWriter creates new EnvelopeWriter, which writes to w.
+func NewEnvelopeWriter(w io.Writer) *EnvelopeWriter {
+	return &EnvelopeWriter{w: w}
+}
+
+// Write writes the envelope as a single line.
+func (ew *EnvelopeWriter) Write(env *Envelope) error {
+	p, err := json.Marshal(env.versioned())
+	if err != nil {
+		return err
+	}
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+	_, err = ew.w.Write(append(p, '\n'))
+	return err
+}
+
+// EnvelopeReader reads a stream of envelopes written by the EnvelopeWriter.
+// Empty lines are skipped.
+type EnvelopeReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+// NewEnvelopeReader creates new EnvelopeReader, which reads from r.
+func NewEnvelopeReader(r io.Reader) *EnvelopeReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 64<<20)
+	return &EnvelopeReader{s: s}
+}
+
+// Read reads next envelope. It returns io.EOF when there are no more
+// envelopes to read.
+func (er *EnvelopeReader) Read() (*Envelope, error) {
+	for er.s.Scan() {
+		er.line++
+		if strings.TrimSpace(er.s.Text()) == "" {
+			continue
+		}
+		env, err := ReadEnvelope(er.s.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", er.line, err)
+		}
+		return env, nil
+	}
+	if err := er.s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEnvelope(t *testing.T, event, delivery, body string) *Envelope {
+	req, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Length", "1234")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", delivery)
+	req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, []byte(body)))
+	req.RemoteAddr = "192.30.252.34:41235"
+	env := NewEnvelope(req, []byte(body))
+	env.ReceivedAt = time.Date(2015, 3, 19, 9, 36, 45, 0, time.UTC)
+	env.Status = http.StatusOK
+	return env
+}
+
+func compact(t *testing.T, p []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, p); err != nil {
+		t.Fatalf("Compact()=%v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestEnvelope(t *testing.T) {
+	env := testEnvelope(t, "push", "1", `{"ref":"refs/heads/master"}`)
+	if env.Version != EnvelopeVersion || env.Event != "push" || env.Delivery != "1" {
+		t.Fatalf("want version=%d event=push delivery=1; got %d %s %s", EnvelopeVersion,
+			env.Version, env.Event, env.Delivery)
+	}
+	var buf bytes.Buffer
+	if err := WriteEnvelope(&buf, env); err != nil {
+		t.Fatalf("WriteEnvelope()=%v", err)
+	}
+	got, err := ReadEnvelope(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ReadEnvelope()=%v", err)
+	}
+	if !reflect.DeepEqual(got.Headers, env.Headers) || !bytes.Equal(compact(t, got.Payload()), env.Payload()) ||
+		got.RemoteAddr != env.RemoteAddr || !got.ReceivedAt.Equal(env.ReceivedAt) || got.Status != env.Status {
+		t.Errorf("want %+v; got %+v", env, got)
+	}
+	req, err := got.Request("http://localhost:8080/")
+	if err != nil {
+		t.Fatalf("Request()=%v", err)
+	}
+	if req.Header.Get("Content-Length") != "" || req.Header.Get("X-GitHub-Event") != "push" ||
+		req.Header.Get("X-Hub-Signature") != env.Headers.Get("X-Hub-Signature") {
+		t.Errorf("unexpected request headers: %v", req.Header)
+	}
+}
+
+func TestReadEnvelope(t *testing.T) {
+	cases := [...]struct {
+		p        string
+		event    string
+		delivery string
+		err      error
+	}{
+		// Envelopes written before the format was versioned.
+		{`{"headers":{"X-Github-Event":["ping"],"X-Github-Delivery":["2"]},"body":{"zen":"Keep it logically awesome."},"status":200}`,
+			"ping", "2", nil},
+		{`{"version":1,"event":"push","delivery":"3","headers":{},"body":"payload=%7B%7D"}`,
+			"push", "3", nil},
+		{`{"ref":"refs/heads/master"}`, "", "", ErrNotEnvelope},
+		{`[{"ref":"refs/heads/master"}]`, "", "", ErrNotEnvelope},
+		{`{"headers":{}}`, "", "", ErrNotEnvelope},
+	}
+	for i, cas := range cases {
+		env, err := ReadEnvelope([]byte(cas.p))
+		if err != cas.err {
+			t.Errorf("want err=%v; got %v (i=%d)", cas.err, err, i)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if env.Version != EnvelopeVersion || env.Event != cas.event || env.Delivery != cas.delivery {
+			t.Errorf("want version=%d event=%s delivery=%s; got %d %s %s (i=%d)", EnvelopeVersion,
+				cas.event, cas.delivery, env.Version, env.Event, env.Delivery, i)
+		}
+	}
+	if _, err := ReadEnvelope([]byte(`{"version":2,"headers":{},"body":{}}`)); err == nil {
+		t.Error("want ReadEnvelope to fail for unsupported version")
+	}
+	if _, err := ReadEnvelope([]byte(`{"headers":`)); err == nil || err == ErrNotEnvelope {
+		t.Errorf("want syntax error; got %v", err)
+	}
+}
+
+func TestReadPayload(t *testing.T) {
+	gz, err := gzipBytes([]byte(`{"headers":{},"body":{"zen":"Half measures are as bad as nothing at all."}}`))
+	if err != nil {
+		t.Fatalf("gzipBytes()=%v", err)
+	}
+	cases := [...]struct {
+		p       []byte
+		payload string
+	}{
+		{[]byte(`{"ref":"refs/heads/master"}`), `{"ref":"refs/heads/master"}`},
+		{[]byte(`{"version":1,"headers":{},"body":{"ref":"refs/heads/master"}}`), `{"ref":"refs/heads/master"}`},
+		{[]byte(`{"version":1,"headers":{},"body":"payload=%7B%7D"}`), `payload=%7B%7D`},
+		{gz, `{"zen":"Half measures are as bad as nothing at all."}`},
+	}
+	for i, cas := range cases {
+		p, err := ReadPayload(cas.p)
+		if err != nil {
+			t.Errorf("ReadPayload()=%v (i=%d)", err, i)
+			continue
+		}
+		if string(p) != cas.payload {
+			t.Errorf("want payload=%s; got %s (i=%d)", cas.payload, p, i)
+		}
+	}
+	if _, err := ReadPayload([]byte(`{"version":2,"headers":{},"body":{}}`)); err == nil {
+		t.Error("want ReadPayload to fail for unsupported version")
+	}
+}
+
+func TestEnvelopeStream(t *testing.T) {
+	envs := []*Envelope{
+		testEnvelope(t, "push", "1", `{"ref":"refs/heads/master"}`),
+		testEnvelope(t, "ping", "2", `{"zen":"Design for failure."}`),
+		testEnvelope(t, "push", "3", "payload=%7B%7D"),
+	}
+	var buf bytes.Buffer
+	w := NewEnvelopeWriter(&buf)
+	for i, env := range envs {
+		if err := w.Write(env); err != nil {
+			t.Fatalf("Write()=%v (i=%d)", err, i)
+		}
+		if i == 0 {
+			buf.WriteString("\n")
+		}
+	}
+	r := NewEnvelopeReader(&buf)
+	for i := 0; ; i++ {
+		env, err := r.Read()
+		if err == io.EOF {
+			if i != len(envs) {
+				t.Errorf("want %d envelopes; got %d", len(envs), i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read()=%v (i=%d)", err, i)
+		}
+		if i >= len(envs) {
+			t.Fatalf("unexpected envelope: %+v", env)
+		}
+		if env.Delivery != envs[i].Delivery || !bytes.Equal(env.Payload(), envs[i].Payload()) {
+			t.Errorf("want delivery=%s payload=%s; got %s %s (i=%d)", envs[i].Delivery,
+				envs[i].Payload(), env.Delivery, env.Payload(), i)
+		}
+	}
+	r = NewEnvelopeReader(strings.NewReader("\n{\"ref\":\"refs/heads/master\"}\n"))
+	if _, err := r.Read(); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("want error for line 2; got %v", err)
+	}
+}
@@ -1,7 +1,10 @@
 package webhook
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"text/template"
 	"unicode"
@@ -42,7 +45,99 @@ func (bh BlanketHandler) All(event string, _ interface{}) {
 }
 `
 
+const handlers = `// Created by go generate; DO NOT EDIT
+
+package webhooktest
+
+import "github.com/rjeczalik/gh/webhook"
+
+// DetailHandler is a webhook service, which handles each event type with
+// the dedicated method and records it.
+type DetailHandler struct {
+	Recorder *Recorder
+}
+
+// NewDetailHandler creates new DetailHandler with an empty recorder.
+func NewDetailHandler() *DetailHandler {
+	return &DetailHandler{Recorder: &Recorder{}}
+}
+{{range $event, $_ := .}}
+// {{camelCase $event}} records the {{$event}} event.
+func (dh *DetailHandler) {{camelCase $event}}(e *webhook.{{camelCase $event}}Event) {
+	dh.Recorder.Record("{{$event}}", e)
+}
+{{end}}
+// BlanketHandler is a webhook service, which handles all the events with
+// a single method and records them.
+type BlanketHandler struct {
+	Recorder *Recorder
+}
+
+// NewBlanketHandler creates new BlanketHandler with an empty recorder.
+func NewBlanketHandler() *BlanketHandler {
+	return &BlanketHandler{Recorder: &Recorder{}}
+}
+
+// All records the event.
+func (bh *BlanketHandler) All(event string, payload interface{}) {
+	bh.Recorder.Record(event, payload)
+}
+`
+
+const builders = `// Created by go generate; DO NOT EDIT
+
+package webhooktest
+
+import "github.com/rjeczalik/gh/webhook"
+{{range $event, $action := .}}
+// New{{camelCase $event}}Event builds the {{$event}} event payload.{{if $action}}
+// The default action is "{{$action}}".{{end}}
+func New{{camelCase $event}}Event(opts ...Option) *webhook.{{camelCase $event}}Event {
+	e := &webhook.{{camelCase $event}}Event{}
+	build(e, "{{$action}}", opts)
+	return e
+}
+{{end}}
+var builders = map[string]func(...Option) webhook.Event{
+{{range $event, $_ := .}}	"{{$event}}": func(opts ...Option) webhook.Event { return New{{camelCase $event}}Event(opts...) },
+{{end}}}
+`
+
 var tmplMock = template.Must(template.New("mock_test").Funcs(fn).Parse(mock))
+var tmplHandlers = template.Must(template.New("handlers").Funcs(fn).Parse(handlers))
+var tmplBuilders = template.Must(template.New("builders").Funcs(fn).Parse(builders))
+
+// actions gives the action of each event as read from its testdata file,
+// the action is empty for events which do not have one.
+func actions() (map[string]string, error) {
+	m := make(map[string]string, len(payloads))
+	for event := range payloads {
+		p, err := ioutil.ReadFile(filepath.Join("testdata", event+".json"))
+		if err != nil {
+			return nil, err
+		}
+		var v struct {
+			Action string `json:"action"`
+		}
+		if err := json.Unmarshal(p, &v); err != nil {
+			return nil, err
+		}
+		m[event] = v.Action
+	}
+	return m, nil
+}
+
+func generate(file string, tmpl *template.Template, data interface{}) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if err = nonil(tmpl.Execute(f, data), f.Sync(), f.Close()); err != nil {
+		os.Remove(file)
+		return err
+	}
+	return nil
+}
 
 func TestGenerateMockHelper(t *testing.T) {
 	var do bool
@@ -55,12 +150,17 @@ func TestGenerateMockHelper(t *testing.T) {
 	if !do {
 		t.Skip("usage: go test -run TestGenerateMockHelper -- -generate")
 	}
-	f, err := os.OpenFile("mock_test.go", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err := generate("mock_test.go", tmplMock, payloads); err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(filepath.Join("webhooktest", "handlers.go"), tmplHandlers, payloads); err != nil {
+		t.Fatal(err)
+	}
+	act, err := actions()
 	if err != nil {
 		t.Fatal(err)
 	}
-	if err = nonil(tmplMock.Execute(f, payloads), f.Sync(), f.Close()); err != nil {
-		os.Remove("mock_test.go")
+	if err := generate(filepath.Join("webhooktest", "builders.go"), tmplBuilders, act); err != nil {
 		t.Fatal(err)
 	}
 }
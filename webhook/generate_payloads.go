@@ -20,6 +20,7 @@ import (
 	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/rjeczalik/gh/webhook"
 )
 
 const docURL = "https://developer.github.com/v3/activity/events/types"
@@ -35,6 +36,8 @@ type member struct {
 	Name string
 	Typ  string
 	Tag  string
+
+	guessed bool // whether Typ was looked up in hardcodedTypes
 }
 
 type object struct {
@@ -91,8 +94,17 @@ func (ms *memberSet) Add(m member) {
 				(*ms)[i].Typ = "Time"
 				break
 			}
-			if m.Name == "Assignee" {
-				(*ms)[i].Typ = "User"
+			// Type of a member, which was null in a payload, is only a guess;
+			// prefer the one which was read from an actual value.
+			if m.guessed {
+				break
+			}
+			if (*ms)[i].guessed {
+				(*ms)[i] = m
+				break
+			}
+			if isNumber(typ) && isNumber(m.Typ) {
+				(*ms)[i].Typ = "json.Number"
 				break
 			}
 			(*ms)[i].Typ = "interface{}"
@@ -146,9 +158,12 @@ func (t typeTree) push(e rawEvent) {
 		typ, v map[string]interface{}
 	}
 	var v map[string]interface{}
-	if err := json.Unmarshal([]byte(e.PayloadJSON), &v); err != nil {
+	dec := json.NewDecoder(strings.NewReader(e.PayloadJSON))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
 		die(err)
 	}
+	markNullable(e.Name, v)
 	typ, ok := t[e.Name].(map[string]interface{})
 	if !ok {
 		t[e.Name] = v
@@ -164,9 +179,17 @@ func (t typeTree) push(e rawEvent) {
 				continue
 			}
 			switch rtyp, rv := reflect.TypeOf(typ), reflect.TypeOf(v); {
-			case rtyp == nil && rv != nil && rv.Kind() == reflect.Map:
-				typ = make(map[string]interface{})
-				nd.typ[k] = typ
+			case rtyp == nil && rv != nil:
+				nd.typ[k] = v
+			case rtyp == rv && rv != nil && rv.Kind() == reflect.Slice:
+				// Type of an empty array can't be guessed, prefer a non-empty one.
+				if len(typ.([]interface{})) == 0 {
+					nd.typ[k] = v
+				}
+			case rtyp == numberType && rv == numberType:
+				if typ != ambiguous && isInt(typ.(json.Number)) != isInt(v.(json.Number)) {
+					nd.typ[k] = ambiguous
+				}
 			case rtyp != nil && rv != nil && rtyp != rv:
 				die(fmt.Sprintf("merge: incompatible types for %s: %T vs %v", k, v, typ))
 			default:
@@ -178,6 +201,88 @@ func (t typeTree) push(e rawEvent) {
 	}
 }
 
+var numberType = reflect.TypeOf(json.Number(""))
+
+// ambiguous marks a number member, which is an integer in some payloads and
+// a floating point value in the others.
+const ambiguous = json.Number("")
+
+func isInt(n json.Number) bool {
+	_, err := n.Int64()
+	return err == nil
+}
+
+// counts lists members, which are counters despite the lack of Count suffix.
+var counts = map[string]bool{
+	"Additions":      true,
+	"ChangedFiles":   true,
+	"Comments":       true,
+	"Commits":        true,
+	"Deletions":      true,
+	"Followers":      true,
+	"Following":      true,
+	"Forks":          true,
+	"OpenIssues":     true,
+	"PublicGists":    true,
+	"PublicRepos":    true,
+	"ReviewComments": true,
+	"Size":           true,
+	"Stargazers":     true,
+	"Total":          true,
+	"Watchers":       true,
+}
+
+// intType gives int64 type for identifiers and counters, as GitHub's IDs do
+// not fit in 32 bits anymore, and int for the rest of the integers.
+func intType(name string) string {
+	if strings.HasSuffix(name, "ID") || strings.HasSuffix(name, "Count") || counts[name] {
+		return "int64"
+	}
+	return "int"
+}
+
+// isScalar reports whether the type is a scalar one, which zero value can't
+// be told apart from a null one. The Time type is not, as its zero value
+// stands for null.
+func isScalar(typ string) bool {
+	return typ == "bool" || typ == "string" || isNumber(typ)
+}
+
+func isNumber(typ string) bool {
+	return typ == "int" || typ == "int64" || typ == "float64" || typ == "json.Number"
+}
+
+// changes is a set of types generated for the "changes" objects; changesValues
+// is its subset, which holds the previous values in the "from" member.
+var changes = make(map[string]bool)
+var changesValues = make(map[string]bool)
+
+// nullable is a set of members, which were null in at least one of the payloads.
+// It's keyed with "<object name>.<member name>". The members, which GitHub
+// computes in the background, are null until they're computed, which is not
+// captured by every example payload, thus they're marked by hand.
+var nullable = map[string]bool{
+	"PullRequest.Mergeable":  true,
+	"PullRequest.Rebaseable": true,
+}
+
+func markNullable(name string, v map[string]interface{}) {
+	for k, v := range v {
+		switch v := v.(type) {
+		case nil:
+			nullable[name+"."+camelCase(k)] = true
+		case map[string]interface{}:
+			markNullable(camelCase(k), v)
+		case []interface{}:
+			for _, v := range v {
+				if v, ok := v.(map[string]interface{}); ok {
+					markNullable(camelCase(k), v)
+				}
+			}
+		}
+	}
+}
+
 func (t typeTree) objects() (obj []object) {
 	var stack = make([]node, 0, len(t))
 	for k, v := range t {
@@ -191,6 +296,7 @@ func (t typeTree) objects() (obj []object) {
 	var nd node
 	for n := len(stack); n != 0; n = len(stack) {
 		nd, stack = stack[n-1], stack[:n-1]
+		_, event := t[nd.name]
 		o := object{Name: nd.name, Members: make([]member, 0, len(nd.nodes))}
 		for k, v := range nd.nodes {
 			// Ignore "_links" member as it's redundant and it pollutes a number
@@ -200,21 +306,146 @@ func (t typeTree) objects() (obj []object) {
 			}
 			m := member{Name: camelCase(k), Tag: k}
 			setType(&m, v, nd.name, &stack)
+			// The "changes" object holds previous values of the members of
+			// an edited object. Its types are prefixed with the event name,
+			// so they don't get merged with the types of the edited objects.
+			// Only changed members are present, so they're pointers.
+			if m.Typ == m.Name && (nd.changes || event && k == "changes") {
+				name := nd.name + m.Name
+				if !nd.changes {
+					name = strings.TrimSuffix(nd.name, "Event") + "Changes"
+				}
+				changes[name] = true
+				m.Typ = "*" + name
+				stack[len(stack)-1].name = name
+				stack[len(stack)-1].changes = true
+			}
+			// Members shared by all the events are declared by the embedded
+			// Common struct, the object types are generated nevertheless.
+			if event && commonMembers[k] {
+				continue
+			}
 			(*memberSet)(&o.Members).Add(m)
 		}
 		(*objectSet)(&obj).Add(o)
 	}
-	return obj
+	// Nullable members of object and scalar types are turned into pointers,
+	// so a missing value can be told apart from a zero one.
+	structs := make(map[string]bool, len(obj))
+	for _, o := range obj {
+		structs[o.Name] = true
+		if changes[o.Name] && typeOf(o, "From") != "" {
+			changesValues[o.Name] = true
+		}
+	}
+	for _, o := range obj {
+		for i, m := range o.Members {
+			if nullable[o.Name+"."+m.Name] && (structs[m.Typ] || isScalar(m.Typ)) {
+				o.Members[i].Typ = "*" + m.Typ
+			}
+		}
+	}
+	return unify(obj)
+}
+
+type shape struct {
+	Name    string   // name of the shared type
+	Members []string // members which identify the shape
+}
+
+// Objects of the same shape are merged into a single shared type, e.g. sender,
+// owner or assignee objects are all users.
+var shapes = []shape{
+	{"User", []string{"AvatarURL", "ID", "Login", "Type"}},
+	{"Repository", []string{"FullName", "Owner", "Private"}},
+	{"PushCommit", []string{"Distinct", "ID", "Message", "Timestamp"}},
+}
+
+type alias struct {
+	Name   string
+	Target string
+}
+
+// aliases keeps names of the merged types, so they're still available as
+// aliases for the shared ones.
+var aliases []alias
+
+// released lists names of the merged types, which were generated before
+// the types were shared. Only they are kept as aliases, the other ones
+// were never exported.
+var released = map[string]bool{
+	"Assignee":   true,
+	"Author":     true,
+	"Commits":    true,
+	"Committer":  true,
+	"Creator":    true,
+	"Forkee":     true,
+	"HeadCommit": true,
+	"Member":     true,
+	"Owner":      true,
+	"Pusher":     true,
+	"Repo":       true,
+	"Sender":     true,
+	"Uploader":   true,
+}
+
+func (sh shape) match(o object) bool {
+	if isEvent(o.Name) {
+		return false
+	}
+	for _, name := range sh.Members {
+		if typeOf(o, name) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func unify(obj []object) []object {
+	target := make(map[string]string)
+	for _, o := range obj {
+		for _, sh := range shapes {
+			if sh.match(o) {
+				if o.Name != sh.Name {
+					target[o.Name] = sh.Name
+					if released[o.Name] {
+						aliases = append(aliases, alias{Name: o.Name, Target: sh.Name})
+					}
+				}
+				break
+			}
+		}
+	}
+	for _, o := range obj {
+		for i, m := range o.Members {
+			typ := strings.TrimLeft(m.Typ, "*[]")
+			if t, ok := target[typ]; ok {
+				o.Members[i].Typ = m.Typ[:len(m.Typ)-len(typ)] + t
+			}
+		}
+	}
+	var unified []object
+	for _, o := range obj {
+		if t, ok := target[o.Name]; ok {
+			o.Name = t
+		}
+		(*objectSet)(&unified).Add(o)
+	}
+	return unified
 }
 
 const header = `// Created by go generate; DO NOT EDIT
 
 package webhook
 
-import "reflect"
-
+{{if .Number}}import (
+	"encoding/json"
+	"reflect"
+)
+{{else}}import "reflect"
+{{end}}
 var payloads = payloadsMap{
-{{range $_, $event := .}}	"{{snakeCase $event}}": reflect.TypeOf((*{{$event}})(nil)).Elem(),
+{{range $_, $event := .Events}}	"{{snakeCase $event}}": reflect.TypeOf((*{{$event}})(nil)).Elem(),
 {{end}}
 }
 `
@@ -222,7 +453,9 @@ var payloads = payloadsMap{
 const types = `{{range $_, $o := .}}// {{$o.Name}} was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type {{$o.Name}} struct {
-{{range $_, $m := $o.Members}}	{{$m.Name}} {{$m.Typ}} ` + "`json:\"{{$m.Tag}}\"`" + `
+{{if isEvent $o.Name}}	Common
+
+{{end}}{{range $_, $m := $o.Members}}	{{$m.Name}} {{$m.Typ}} ` + "`json:\"{{$m.Tag}}\"`" + `
 {{end}}
 }
 {{end}}
@@ -231,35 +464,95 @@ type {{$o.Name}} struct {
 type Files map[string]File
 `
 
+const methods = `{{range $_, $o := .}}{{if isEvent $o.Name}}
+// EventName implements the Event interface.
+func (e *{{$o.Name}}) EventName() string {
+	return "{{snakeCase $o.Name}}"
+}
+
+// GetRepository implements the Event interface.
+func (e *{{$o.Name}}) GetRepository() *Repository {
+{{if eq (typeOf $o "Repository") "Repository"}}	return &e.Repository
+{{else}}	return nil
+{{end}}}
+
+// GetSender implements the Event interface.
+func (e *{{$o.Name}}) GetSender() *User {
+{{if eq (typeOf $o "Sender") "User"}}	return &e.Sender
+{{else}}	return nil
+{{end}}}
+
+// GetAction implements the Event interface.
+func (e *{{$o.Name}}) GetAction() string {
+{{if eq (typeOf $o "Action") "string"}}	return e.Action
+{{else}}	return ""
+{{end}}}
+{{end}}{{end}}{{range $_, $o := .}}{{if and (isChanges $o.Name) (eq (typeOf $o "From") "")}}
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *{{$o.Name}}) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+{{range $_, $m := $o.Members}}{{if isChangesValue (elem $m.Typ)}}	if c.{{$m.Name}} != nil {
+		fields = append(fields, "{{$m.Tag}}")
+	}
+{{else}}	if c.{{$m.Name}} != nil {
+		for _, f := range c.{{$m.Name}}.Fields() {
+			fields = append(fields, "{{$m.Tag}}."+f)
+		}
+	}
+{{end}}{{end}}	return fields
+}
+{{end}}{{end}}`
+
+const aliasTypes = `{{range $_, $a := .}}
+// {{$a.Name}} is an alias for the {{$a.Target}} type, which is shared by
+// the structurally equivalent payload objects.
+type {{$a.Name}} = {{$a.Target}}
+{{end}}`
+
 var tmplHeader = template.Must(template.New("payloads").Funcs(map[string]interface{}{"snakeCase": snakeCase}).Parse(header))
-var tmplTypes = template.Must(template.New("payloads").Parse(types))
+var tmplTypes = template.Must(template.New("payloads").Funcs(map[string]interface{}{"isEvent": isEvent}).Parse(types))
+var tmplAliases = template.Must(template.New("payloads").Parse(aliasTypes))
+var tmplMethods = template.Must(template.New("payloads").Funcs(map[string]interface{}{"isEvent": isEvent, "snakeCase": snakeCase, "typeOf": typeOf, "isChanges": isChanges, "isChangesValue": isChangesValue, "elem": elem}).Parse(methods))
+
+// Those top-level keys are carried by deliveries of every event type, they're
+// declared once by the Common struct which is embedded in each *Event struct.
+var commonMembers = map[string]bool{
+	"installation": true,
+	"organization": true,
+	"enterprise":   true,
+}
 
 // Those keys that are assigned to null in example JSON payloads lack type
 // information. Instead the value types are mapped here by hand.
 var hardcodedTypes = map[string]string{
-	"user":             "User",
-	"assignee":         "User",
-	"position":         "int",
-	"code":             "int",
-	"line":             "int",
-	"closed_at":        "Time",
-	"merged_at":        "Time",
-	"body":             "string",
-	"path":             "string",
-	"homepage":         "string",
-	"language":         "string",
-	"mirror_url":       "string",
-	"milestone":        "Milestone",
-	"message":          "string",
-	"mergeable":        "bool",
-	"merged_by":        "User",
-	"merge_commit_sha": "string",
-	"base_ref":         "string",
-	"summary":          "string",
-	"name":             "string",
-	"target_url":       "string",
-	"description":      "string",
-	"label":            "string",
+	"user":               "User",
+	"assignee":           "User",
+	"position":           "int",
+	"code":               "int",
+	"line":               "int",
+	"closed_at":          "Time",
+	"merged_at":          "Time",
+	"body":               "string",
+	"path":               "string",
+	"homepage":           "string",
+	"language":           "string",
+	"mirror_url":         "string",
+	"milestone":          "Milestone",
+	"message":            "string",
+	"mergeable":          "bool",
+	"merged_by":          "User",
+	"merge_commit_sha":   "string",
+	"base_ref":           "string",
+	"summary":            "string",
+	"name":               "string",
+	"target_url":         "string",
+	"description":        "string",
+	"label":              "string",
+	"active_lock_reason": "string",
 }
 
 // File is a value of gist's Files map, it's handled separately as
@@ -271,7 +564,7 @@ var hardcodedFileType = object{
 	Members: []member{
 		{
 			Name: "Size",
-			Typ:  "int",
+			Typ:  "int64",
 			Tag:  "size",
 		},
 		{
@@ -316,6 +609,42 @@ func die(v interface{}) {
 func init() {
 }
 
+// typeOf gives a type of the o's member or an empty string, if there's no such
+// member.
+func typeOf(o object, name string) string {
+	if i := memberSet(o.Members).Search(name); i != len(o.Members) && o.Members[i].Name == name {
+		return o.Members[i].Typ
+	}
+	return ""
+}
+
+func usesNumber(obj []object) bool {
+	for _, o := range obj {
+		for _, m := range o.Members {
+			if strings.HasSuffix(m.Typ, "json.Number") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isChanges(name string) bool {
+	return changes[name]
+}
+
+func isChangesValue(name string) bool {
+	return changesValues[name]
+}
+
+func elem(typ string) string {
+	return strings.TrimPrefix(typ, "*")
+}
+
+func isEvent(name string) bool {
+	return strings.HasSuffix(name, "Event")
+}
+
 func snakeCase(s string) (t string) {
 	if i := strings.Index(s, "Event"); i != -1 {
 		s = s[:i]
@@ -410,8 +739,9 @@ func pingEvent() rawEvent {
 }
 
 type node struct {
-	name  string
-	nodes map[string]interface{}
+	name    string
+	nodes   map[string]interface{}
+	changes bool // whether the node is a part of "changes" object
 }
 
 var setType = func() func(*member, interface{}, string, *[]node) {
@@ -424,6 +754,14 @@ var setType = func() func(*member, interface{}, string, *[]node) {
 				// invalid recursive types.
 				break
 			}
+			// ClientPayload is a member of a repository_dispatch event, its
+			// content is defined by the dispatching client, so it's not typed.
+			//
+			// https://docs.github.com/webhooks/webhook-events-and-payloads#repository_dispatch
+			if m.Tag == "client_payload" {
+				m.Typ = "map[string]interface{}"
+				break
+			}
 			m.Typ = m.Name
 			// Files is a member of a gist object, it's handled separately since
 			// it's a map.
@@ -434,8 +772,15 @@ var setType = func() func(*member, interface{}, string, *[]node) {
 			}
 		case bool:
 			m.Typ = "bool"
-		case float64:
-			m.Typ = "int"
+		case json.Number:
+			switch {
+			case v == ambiguous:
+				m.Typ = "json.Number"
+			case isInt(v):
+				m.Typ = intType(m.Name)
+			default:
+				m.Typ = "float64"
+			}
 		case []interface{}:
 			if len(v) == 0 {
 				m.Typ = "[]string"
@@ -464,6 +809,7 @@ var setType = func() func(*member, interface{}, string, *[]node) {
 				die(fmt.Sprintf("unable to guess type for %s: %T", m.Name, v))
 			}
 			m.Typ = typ
+			m.guessed = true
 		}
 
 	}
@@ -513,7 +859,7 @@ func readTestdata() (events []rawEvent) {
 		die(err)
 	}
 	for _, fi := range fis {
-		event := strings.ToLower(fi.Name())
+		event := strings.TrimSuffix(strings.ToLower(fi.Name()), ".gz")
 		if !strings.HasSuffix(event, ".json") {
 			log.Println("webhook: ignoring", fi.Name())
 			continue
@@ -532,7 +878,11 @@ func readTestdata() (events []rawEvent) {
 		if err != nil {
 			die(err)
 		}
-		events = append(events, rawEvent{Name: event, PayloadJSON: string(body)})
+		payload, err := webhook.ReadPayload(body)
+		if err != nil {
+			die(err)
+		}
+		events = append(events, rawEvent{Name: event, PayloadJSON: string(payload)})
 	}
 	return events
 }
@@ -562,10 +912,24 @@ func main() {
 			die(fmt.Sprintf("empty payload for %q event (i=%d)", events[i].Name, i))
 		}
 	}
-	if err := tmplHeader.Execute(f, unique(events)); err != nil {
+	obj := newTypeTree(events).objects()
+	header := struct {
+		Events []string
+		Number bool
+	}{
+		Events: unique(events),
+		Number: usesNumber(obj),
+	}
+	if err := tmplHeader.Execute(f, header); err != nil {
+		die(err)
+	}
+	if err := tmplTypes.Execute(f, obj); err != nil {
+		die(err)
+	}
+	if err := tmplAliases.Execute(f, aliases); err != nil {
 		die(err)
 	}
-	if err := tmplTypes.Execute(f, newTypeTree(events).objects()); err != nil {
+	if err := tmplMethods.Execute(f, obj); err != nil {
 		die(err)
 	}
 	if err := nonil(f.Sync(), f.Close()); err != nil {
@@ -15,11 +15,14 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 
 	"golang.org/x/net/context"
 )
 
-const maxPayloadLen = 1024 * 1024 // 1MiB
+// DefaultMaxPayload is the default maximum size of a payload, it's the maximum
+// size of payloads delivered by GitHub.
+const DefaultMaxPayload = 25 * 1024 * 1024 // 25MiB
 
 var (
 	errMethod      = errors.New("invalid HTTP method")
@@ -28,9 +31,13 @@ var (
 	errSigKind     = errors.New("unsupported signature hash type")
 	errPayload     = errors.New("unsupported payload type")
 	errContentType = errors.New("unsupported content type")
+	errSource      = errors.New("source address is not allowed")
+	errEmpty       = errors.New("empty payload")
+	errTooLarge    = errors.New("payload too large")
 )
 
 var empty = reflect.TypeOf(func(interface{}) {}).In(0)
+var eventType = reflect.TypeOf((*Event)(nil)).Elem()
 var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
 
 type contextKey struct {
@@ -70,6 +77,25 @@ func (w *recWriter) Flush() {
 	panic("http.ResponseWriter does not implement http.Flusher")
 }
 
+// discardWriter is a http.ResponseWriter for events, which are handled
+// after the response was written.
+type discardWriter struct {
+	header http.Header
+}
+
+func (w *discardWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+	return w.header
+}
+
+func (w *discardWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (w *discardWriter) WriteHeader(int) {}
+
 var (
 	// RequestKey is a context key. It can be used in webhook handlers
 	// to access a copy of the *http.Request which is safe to modify
@@ -78,7 +104,9 @@ var (
 
 	// ResponseWriterKey is a context key. It can be used in webhook
 	// handlers to access the original http.ResponseWriter to write
-	// the response directly to client.
+	// the response directly to client. The events, which are handled
+	// after the client received the response, get a writer, which only
+	// records the status for the Dumper, if any.
 	ResponseWriterKey = &contextKey{"response-writer"}
 )
 
@@ -100,7 +128,7 @@ LoopMethods:
 				log.Println("method", mname, "takes wrong type of event:", eventType)
 				continue LoopMethods
 			}
-			event, ok := payloads.Name(eventType.Elem())
+			event, ok := eventName(eventType.Elem())
 			if !ok {
 				log.Println("method", mname, "takes wrong type of event:", eventType)
 				continue LoopMethods
@@ -112,7 +140,7 @@ LoopMethods:
 		case 3:
 			if mtype.In(1).Implements(contextType) && mtype.In(2).Kind() == reflect.Ptr {
 				eventType := mtype.In(2)
-				event, ok := payloads.Name(eventType.Elem())
+				event, ok := eventName(eventType.Elem())
 				if !ok {
 					log.Println("method", mname, "takes wrong type of event:", eventType)
 					continue LoopMethods
@@ -123,7 +151,7 @@ LoopMethods:
 				methods[event] = method
 				continue
 			}
-			if mtype.In(1).Kind() != reflect.String || mtype.In(2) != empty {
+			if mtype.In(1).Kind() != reflect.String || (mtype.In(2) != empty && mtype.In(2) != eventType) {
 				log.Println("wildcard method", mname, "takes wrong types of arguments")
 				continue LoopMethods
 			}
@@ -155,9 +183,56 @@ type Handler struct {
 	// If nil, event handlers creates empty context objects
 	ContextFunc func(*http.Request) context.Context
 
+	// MaxPayload is the maximum size of a payload in bytes, larger requests
+	// are rejected with 413 Request Entity Too Large. If 0, DefaultMaxPayload
+	// is used instead.
+	MaxPayload int64
+
+	// Allowlist, if non-nil, makes the Handler reject requests, which do not
+	// come from the allowed network ranges, with 403 Forbidden.
+	Allowlist *Allowlist
+
+	// Spool, if non-nil, makes the Handler write each verified delivery to
+	// the spool and respond with 202 Accepted only after the delivery was
+	// synced to the disk. The deliveries are then handled by the workers,
+	// which consume the spool. The workers are started with the first
+	// request or by calling Start.
+	//
+	// Since the response is written before the delivery is handled, the event
+	// handlers, which take a context, get a ResponseWriterKey value, which
+	// discards the writes.
+	Spool *Spool
+
+	// Retry, if non-nil, makes the Handler retry calling the service's
+	// methods, which return a non-nil error. The context passed to the
+	// methods holds the number of the attempt under the AttemptKey.
+	Retry *RetryPolicy
+
+	// Retries, if non-nil, maps names of events to their retry policies,
+	// which take precedence over the Retry one.
+	Retries map[string]*RetryPolicy
+
+	// DeadLetters, if non-nil, stores the deliveries, which failed after
+	// all the attempts, so they can be inspected and handled again later.
+	DeadLetters *DeadLetters
+
+	// RateLimits, if non-empty, limits the rate of the deliveries, e.g. per
+	// repository or sender. The limits are applied after the delivery was
+	// verified. If any of them rejects the delivery with 429 Too Many
+	// Requests, it takes no tokens from the others, otherwise the delivery
+	// is delayed by the longest of the delays.
+	RateLimits []*RateLimit
+
+	// Workers is a number of workers consuming the Spool. If 0, a single
+	// worker is used, thus the deliveries are handled in the order they
+	// were received.
+	Workers int
+
 	secret string                    // value for X-Hub-Signature
 	rcvr   reflect.Value             // receiver of methods for the service
 	method map[string]reflect.Method // event handling methods
+	wg     sync.WaitGroup            // tracks events being handled
+	start  sync.Once                 // starts the Spool workers
 }
 
 // New creates new middleware and registers receiver's method for event handling.
@@ -178,12 +253,23 @@ func New(secret string, rcvr interface{}) *Handler {
 func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	event := req.Header.Get("X-GitHub-Event")
 	sig := strings.Split(req.Header.Get("X-Hub-Signature"), "=")
+	if h.Allowlist != nil {
+		if ip, ok := h.Allowlist.Allow(req); !ok {
+			h.logf("ERROR %s: Status=403 X-GitHub-Event=%q Client=%v Rejected=%d: %v", req.RemoteAddr,
+				req.Header.Get("X-GitHub-Event"), ip, h.Allowlist.Rejected(), errSource)
+			http.Error(w, errSource.Error(), http.StatusForbidden)
+			return
+		}
+	}
 	switch content := strings.Split(req.Header.Get("Content-Type"), ";"); {
 	case req.Method != "POST":
 		h.fatal(w, req, http.StatusMethodNotAllowed, errMethod)
 		return
-	case req.ContentLength <= 0 || req.ContentLength > maxPayloadLen:
-		h.fatal(w, req, http.StatusBadRequest, errHeaders)
+	case req.ContentLength == 0:
+		h.fatal(w, req, http.StatusBadRequest, errEmpty)
+		return
+	case req.ContentLength > h.maxPayload():
+		h.fatal(w, req, http.StatusRequestEntityTooLarge, errTooLarge)
 		return
 	case event == "" || len(sig) != 2:
 		h.fatal(w, req, http.StatusBadRequest, errHeaders)
@@ -195,75 +281,303 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.fatal(w, req, http.StatusBadRequest, errContentType)
 		return
 	}
-	body := bytes.NewBuffer(make([]byte, 0, int(req.ContentLength)))
-	if _, err := io.Copy(body, req.Body); err != nil {
+	// The Content-Length is unknown for chunked requests, thus the limit
+	// is enforced while reading the body as well.
+	max, n := h.maxPayload(), req.ContentLength
+	if n < 0 {
+		n = 0
+	}
+	body := bytes.NewBuffer(make([]byte, 0, int(n)))
+	if _, err := io.Copy(body, http.MaxBytesReader(w, req.Body, max)); err != nil {
+		if int64(body.Len()) >= max {
+			h.fatal(w, req, http.StatusRequestEntityTooLarge, errTooLarge)
+			return
+		}
 		h.fatal(w, req, http.StatusInternalServerError, err)
 		return
 	}
+	if body.Len() == 0 {
+		h.fatal(w, req, http.StatusBadRequest, errEmpty)
+		return
+	}
 	if !hmac.Equal([]byte(hmacHexDigest(h.secret, body.Bytes())), []byte(sig[1])) {
 		h.fatal(w, req, http.StatusUnauthorized, errSig)
 		return
 	}
-	typ, ok := payloads.Type(event)
-	if !ok {
-		h.fatal(w, req, http.StatusBadRequest, errPayload)
+	payload, err := decodePayload(event, body.Bytes())
+	if err != nil {
+		h.fatal(w, req, http.StatusBadRequest, err)
 		return
 	}
-	v := reflect.New(typ)
-	if err := json.NewDecoder(body).Decode(v.Interface()); err != nil {
-		h.fatal(w, req, http.StatusBadRequest, err)
+	if h.Spool != nil {
+		if _, wait, err := h.limit(event, payload, nil); err != nil {
+			h.tooMany(w, req, wait)
+			return
+		}
+		env := NewEnvelope(req, body.Bytes())
+		env.Status = http.StatusAccepted
+		id, err := h.Spool.Put(env)
+		if err != nil {
+			h.fatal(w, req, http.StatusInternalServerError, err)
+			return
+		}
+		h.Start()
+		w.WriteHeader(http.StatusAccepted)
+		h.logf("INFO %s: Status=202 X-GitHub-Event=%q Spool=%q", req.RemoteAddr, event, id)
 		return
 	}
 	reqCopy := copyRequest(req)
 	reqCopy.Body = ioutil.NopCloser(bytes.NewReader(body.Bytes()))
 	reqCopy.ContentLength = int64(body.Len())
-	go h.handle(event, v.Interface(), w, reqCopy)
+	h.wg.Add(1)
+	run := func(w http.ResponseWriter) {
+		defer h.wg.Done()
+		if w == nil {
+			h.logf("INFO %s: X-GitHub-Event=%q X-GitHub-Delivery=%q: skipped, coalesced with a later delivery",
+				reqCopy.RemoteAddr, event, reqCopy.Header.Get("X-GitHub-Delivery"))
+			return
+		}
+		if err := h.handle(event, payload, w, reqCopy); err != nil {
+			h.deadLetter(NewEnvelope(reqCopy, body.Bytes()), err)
+		}
+	}
+	switch delayed, wait, err := h.limit(event, payload, run); {
+	case err != nil:
+		h.wg.Done()
+		h.tooMany(w, req, wait)
+	case delayed:
+		w.WriteHeader(http.StatusAccepted)
+		h.logf("INFO %s: Status=202 X-GitHub-Event=%q: rate limit exceeded, delayed by %v", req.RemoteAddr, event, wait)
+	default:
+		w, done := dispatching(w)
+		go func() {
+			defer done()
+			run(w)
+		}()
+	}
+}
+
+// dispatchWriter is a http.ResponseWriter, which is notified when the event
+// dispatched asynchronously was handled, e.g. so the Dumper knows the final
+// status of the response. It must be safe to write to after ServeHTTP
+// returned.
+type dispatchWriter interface {
+	http.ResponseWriter
+	dispatching() (done func())
+}
+
+// dispatching gives the writer for the event, which is handled after
+// ServeHTTP returned. Other writers than dispatchWriter are replaced with
+// a discardWriter, as their response was already finished.
+func dispatching(w http.ResponseWriter) (http.ResponseWriter, func()) {
+	if dw, ok := w.(dispatchWriter); ok {
+		return dw, dw.dispatching()
+	}
+	return &discardWriter{}, func() {}
+}
+
+func (h *Handler) maxPayload() int64 {
+	if h.MaxPayload > 0 {
+		return h.MaxPayload
+	}
+	return DefaultMaxPayload
 }
 
-func (h *Handler) handle(event string, payload interface{}, w http.ResponseWriter, req *http.Request) {
+func decodePayload(event string, body []byte) (interface{}, error) {
+	typ, ok := payloads.Type(event)
+	if !ok {
+		return nil, errPayload
+	}
+	v := reflect.New(typ)
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// Wait blocks until all the events, which are being handled, are done.
+// The events are dispatched to the service's methods asynchronously, thus
+// Wait can be used for a graceful shutdown. If the Handler uses a Spool,
+// Wait returns after the Spool is closed and the workers are done. The
+// deliveries delayed by the rate limits are waited for as well.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+// Start starts the workers consuming the Spool, so the deliveries left
+// in the spool by the previous run are handled without waiting for
+// a request. It does nothing if the Spool is nil or the workers were
+// already started.
+func (h *Handler) Start() {
+	if h.Spool == nil {
+		return
+	}
+	h.start.Do(func() {
+		n := h.Workers
+		if n <= 0 {
+			n = 1
+		}
+		h.wg.Add(n)
+		for i := 0; i < n; i++ {
+			go h.work()
+		}
+	})
+}
+
+func (h *Handler) work() {
+	defer h.wg.Done()
+	for {
+		id, env, err := h.Spool.Next()
+		if err != nil {
+			return
+		}
+		h.handleEnvelope(env)
+		if err := h.Spool.Done(id); err != nil {
+			h.logf("ERROR %s: X-GitHub-Event=%q Spool=%q: %v", env.RemoteAddr, env.Event, id, err)
+		}
+	}
+}
+
+// handleEnvelope dispatches the spooled delivery.
+func (h *Handler) handleEnvelope(env *Envelope) {
+	if err := h.Dispatch(env); err != nil {
+		h.deadLetter(env, err)
+	}
+}
+
+// Dispatch decodes the delivery and synchronously dispatches it to
+// the service's methods, retrying the failed ones according to the retry
+// policy. It gives the first error, which was returned by the methods
+// after all the attempts.
+//
+// The event handlers, which take a context, get a ResponseWriterKey value,
+// which discards the writes.
+func (h *Handler) Dispatch(env *Envelope) error {
+	payload, err := decodePayload(env.Event, env.Payload())
+	if err != nil {
+		h.logf("ERROR %s: X-GitHub-Event=%q X-GitHub-Delivery=%q: %v", env.RemoteAddr, env.Event, env.Delivery, err)
+		return err
+	}
+	req, err := env.Request("/")
+	if err != nil {
+		return err
+	}
+	req.RemoteAddr = env.RemoteAddr
+	req.ContentLength = int64(len(env.Payload()))
+	return h.handle(env.Event, payload, &discardWriter{}, req)
+}
+
+// deadLetter stores the delivery, which failed after all the attempts.
+func (h *Handler) deadLetter(env *Envelope, err error) {
+	n := h.policy(env.Event).attempts()
+	if h.DeadLetters == nil {
+		h.logf("ERROR %s: X-GitHub-Event=%q X-GitHub-Delivery=%q: giving up after %d attempts: %v",
+			env.RemoteAddr, env.Event, env.Delivery, n, err)
+		return
+	}
+	env.Attempts, env.Error = n, err.Error()
+	id, e := h.DeadLetters.Put(env)
+	if e != nil {
+		h.logf("ERROR %s: X-GitHub-Event=%q X-GitHub-Delivery=%q: error writing dead letter: %v (%v)",
+			env.RemoteAddr, env.Event, env.Delivery, e, err)
+		return
+	}
+	h.logf("ERROR %s: X-GitHub-Event=%q X-GitHub-Delivery=%q DeadLetter=%q: giving up after %d attempts: %v",
+		env.RemoteAddr, env.Event, env.Delivery, id, n, err)
+}
+
+func (h *Handler) handle(event string, payload interface{}, w http.ResponseWriter, req *http.Request) error {
+	handled, err := h.handleEvent(event, payload, w, req)
+	for _, e := range Derive(payload) {
+		name := e.EventName()
+		method, ok := h.method[name]
+		if !ok {
+			continue
+		}
+		status, e2 := h.call(method, name, e, w, req)
+		if !handled && status == 0 {
+			w.WriteHeader(errStatus(e2))
+		}
+		handled = true
+		if e2 != nil {
+			err = nonil(err, e2)
+			h.logf("ERROR %s: Status=%d X-GitHub-Event=%q Derived=%q Type=%T: %v", req.RemoteAddr, defaultStatus(status), event, name, e, e2)
+			continue
+		}
+		h.logf("INFO %s: Status=%d X-GitHub-Event=%q Derived=%q Type=%T", req.RemoteAddr, defaultStatus(status), event, name, e)
+	}
+	return err
+}
+
+// handleEvent dispatches the delivered event and reports whether it was
+// handled by the service.
+func (h *Handler) handleEvent(event string, payload interface{}, w http.ResponseWriter, req *http.Request) (bool, error) {
 	if method, ok := h.method[event]; ok {
-		status := h.call(method, event, payload, w, req)
+		status, err := h.call(method, event, payload, w, req)
 		if status == 0 {
-			w.WriteHeader(http.StatusNoContent)
+			w.WriteHeader(errStatus(err))
+		}
+		if err != nil {
+			h.logf("ERROR %s: Status=%d X-GitHub-Event=%q Type=%T: %v", req.RemoteAddr, defaultStatus(status), event, payload, err)
+			return true, err
 		}
 		h.logf("INFO %s: Status=%d X-GitHub-Event=%q Type=%T", req.RemoteAddr, defaultStatus(status), event, payload)
-		return
+		return true, nil
 	}
 	if all, ok := h.method["*"]; ok {
-		all.Func.Call([]reflect.Value{h.rcvr, reflect.ValueOf(event), reflect.ValueOf(payload)})
-		w.WriteHeader(http.StatusNoContent)
+		_, err := h.retry(event, w, req, func(int, http.ResponseWriter) (int, error) {
+			return 0, callError(all.Func.Call([]reflect.Value{h.rcvr, reflect.ValueOf(event), reflect.ValueOf(payload)}))
+		})
+		w.WriteHeader(errStatus(err))
+		if err != nil {
+			h.logf("ERROR %s: Status=500 X-GitHub-Event=%q Type=%T: %v", req.RemoteAddr, event, payload, err)
+			return true, err
+		}
 		h.logf("INFO %s: Status=204 X-GitHub-Event=%q Type=%T", req.RemoteAddr, event, payload)
-		return
+		return true, nil
 	}
 	if event == "ping" {
 		w.WriteHeader(http.StatusNoContent)
 		h.logf("INFO %s: Status=204 X-GitHub-Event=ping Events=%v", req.RemoteAddr, payload.(*PingEvent).Hook.Events)
+		return true, nil
 	}
+	return false, nil
 }
 
-func (h *Handler) call(method reflect.Method, event string, payload interface{}, w http.ResponseWriter, req *http.Request) int {
-	switch method.Type.NumIn() {
-	case 2: // without context
-		method.Func.Call([]reflect.Value{h.rcvr, reflect.ValueOf(payload)})
-		return 0
-	case 3: // with context
-		var ctx context.Context
-		var ww = &recWriter{ResponseWriter: w}
-		if h.ContextFunc != nil {
-			ctx = h.ContextFunc(req)
-		} else {
-			ctx = context.Background()
-		}
+// call calls the method with the payload, retrying it according to the retry
+// policy. It gives the status written by the method and the error it returned.
+func (h *Handler) call(method reflect.Method, event string, payload interface{}, w http.ResponseWriter, req *http.Request) (int, error) {
+	return h.retry(event, w, req, func(attempt int, w http.ResponseWriter) (int, error) {
+		switch method.Type.NumIn() {
+		case 2: // without context
+			return 0, callError(method.Func.Call([]reflect.Value{h.rcvr, reflect.ValueOf(payload)}))
+		case 3: // with context
+			var ctx context.Context
+			var ww = &recWriter{ResponseWriter: w}
+			if h.ContextFunc != nil {
+				ctx = h.ContextFunc(req)
+			} else {
+				ctx = context.Background()
+			}
 
-		w = ww
+			w = ww
 
-		ctx = context.WithValue(ctx, RequestKey, req)
-		ctx = context.WithValue(ctx, ResponseWriterKey, w)
-		method.Func.Call([]reflect.Value{h.rcvr, reflect.ValueOf(ctx), reflect.ValueOf(payload)})
-		return ww.status
-	default:
+			ctx = context.WithValue(ctx, RequestKey, req)
+			ctx = context.WithValue(ctx, ResponseWriterKey, w)
+			ctx = context.WithValue(ctx, AttemptKey, attempt)
+			err := callError(method.Func.Call([]reflect.Value{h.rcvr, reflect.ValueOf(ctx), reflect.ValueOf(payload)}))
+			return ww.status, err
+		default:
+			return http.StatusInternalServerError, nil
+		}
+	})
+}
+
+func errStatus(err error) int {
+	if err != nil {
 		return http.StatusInternalServerError
 	}
+	return http.StatusNoContent
 }
 
 func (h *Handler) fatal(w http.ResponseWriter, req *http.Request, code int, err error) {
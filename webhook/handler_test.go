@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -39,6 +41,12 @@ func (Baz) gollum(*GollumEvent)                  {}
 func (Baz) Create(context.Context, *CreateEvent) {}
 func (Baz) Add(int, int) int                     { return 0 }
 
+type Qux struct{}
+
+func (Qux) All(string, Event)   {}
+func (Qux) Watch(*WatchEvent)   {}
+func (Qux) Other(string, error) {}
+
 func TestPayloadMethods(t *testing.T) {
 	cases := [...]struct {
 		rcvr   interface{}
@@ -59,6 +67,11 @@ func TestPayloadMethods(t *testing.T) {
 			Baz{},
 			[]string{"*", "create", "delete", "fork_apply", "gollum"},
 		},
+		// i=3
+		{
+			Qux{},
+			[]string{"*", "watch"},
+		},
 	}
 	for i, cas := range cases {
 		m := payloadMethods(reflect.TypeOf(cas.rcvr))
@@ -118,3 +131,83 @@ func TestHandlerWithBlanket(t *testing.T) {
 		}
 	}
 }
+
+type EventHandler map[string]int
+
+func (eh EventHandler) All(event string, e Event) {
+	if e.EventName() == event {
+		eh[event]++
+	}
+}
+
+func TestHandlerWithEvent(t *testing.T) {
+	h := EventHandler{}
+	testHandler(t, New(secret, h))
+	for event := range payloads {
+		if h[event] != 1 {
+			t.Errorf("want h[%s]=1; got %d", event, h[event])
+		}
+	}
+}
+
+type SlowHandler struct {
+	done chan struct{}
+}
+
+func (sh SlowHandler) Ping(*PingEvent) {
+	time.Sleep(50 * time.Millisecond)
+	close(sh.done)
+}
+
+func TestHandlerWait(t *testing.T) {
+	sh := SlowHandler{done: make(chan struct{})}
+	h := New(secret, sh)
+	body := []byte(`{"zen":"Practicality beats purity."}`)
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-GitHub-Event", "ping")
+	req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, body))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	h.Wait()
+	select {
+	case <-sh.done:
+	default:
+		t.Error("want Wait to block until the event is handled")
+	}
+}
+
+func TestHandlerMaxPayload(t *testing.T) {
+	h := New(secret, BlanketHandler{})
+	h.MaxPayload = 64
+	body := []byte(`{"zen":"Practicality beats purity."}`)
+	large := []byte(`{"zen":"` + strings.Repeat("Practicality beats purity. ", 4) + `"}`)
+	cases := []struct {
+		body    []byte
+		chunked bool
+		code    int
+	}{
+		{body, false, http.StatusOK},
+		{body, true, http.StatusOK},
+		{large, false, http.StatusRequestEntityTooLarge},
+		{large, true, http.StatusRequestEntityTooLarge},
+		{nil, true, http.StatusBadRequest},
+	}
+	for i, cas := range cases {
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(cas.body))
+		if cas.chunked {
+			req.ContentLength = -1
+		}
+		req.Header.Set("X-GitHub-Event", "ping")
+		req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, cas.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		h.Wait()
+		if w.Code != cas.code {
+			t.Errorf("%d: want Code=%d; got %d", i, cas.code, w.Code)
+		}
+	}
+}
@@ -4,6 +4,10 @@ package webhook
 
 type DetailHandler map[string]int
 
+func (dh DetailHandler) BranchProtectionRule(*BranchProtectionRuleEvent) {
+	dh["branch_protection_rule"]++
+}
+
 func (dh DetailHandler) CommitComment(*CommitCommentEvent) {
 	dh["commit_comment"]++
 }
@@ -16,6 +20,10 @@ func (dh DetailHandler) Delete(*DeleteEvent) {
 	dh["delete"]++
 }
 
+func (dh DetailHandler) DeployKey(*DeployKeyEvent) {
+	dh["deploy_key"]++
+}
+
 func (dh DetailHandler) Deployment(*DeploymentEvent) {
 	dh["deployment"]++
 }
@@ -56,6 +64,10 @@ func (dh DetailHandler) Issues(*IssuesEvent) {
 	dh["issues"]++
 }
 
+func (dh DetailHandler) Label(*LabelEvent) {
+	dh["label"]++
+}
+
 func (dh DetailHandler) Member(*MemberEvent) {
 	dh["member"]++
 }
@@ -64,6 +76,26 @@ func (dh DetailHandler) Membership(*MembershipEvent) {
 	dh["membership"]++
 }
 
+func (dh DetailHandler) MergeGroup(*MergeGroupEvent) {
+	dh["merge_group"]++
+}
+
+func (dh DetailHandler) Meta(*MetaEvent) {
+	dh["meta"]++
+}
+
+func (dh DetailHandler) OrgBlock(*OrgBlockEvent) {
+	dh["org_block"]++
+}
+
+func (dh DetailHandler) Organization(*OrganizationEvent) {
+	dh["organization"]++
+}
+
+func (dh DetailHandler) Package(*PackageEvent) {
+	dh["package"]++
+}
+
 func (dh DetailHandler) PageBuild(*PageBuildEvent) {
 	dh["page_build"]++
 }
@@ -80,6 +112,10 @@ func (dh DetailHandler) PullRequest(*PullRequestEvent) {
 	dh["pull_request"]++
 }
 
+func (dh DetailHandler) PullRequestReview(*PullRequestReviewEvent) {
+	dh["pull_request_review"]++
+}
+
 func (dh DetailHandler) PullRequestReviewComment(*PullRequestReviewCommentEvent) {
 	dh["pull_request_review_comment"]++
 }
@@ -88,6 +124,10 @@ func (dh DetailHandler) Push(*PushEvent) {
 	dh["push"]++
 }
 
+func (dh DetailHandler) RegistryPackage(*RegistryPackageEvent) {
+	dh["registry_package"]++
+}
+
 func (dh DetailHandler) Release(*ReleaseEvent) {
 	dh["release"]++
 }
@@ -96,10 +136,30 @@ func (dh DetailHandler) Repository(*RepositoryEvent) {
 	dh["repository"]++
 }
 
+func (dh DetailHandler) RepositoryDispatch(*RepositoryDispatchEvent) {
+	dh["repository_dispatch"]++
+}
+
+func (dh DetailHandler) RepositoryImport(*RepositoryImportEvent) {
+	dh["repository_import"]++
+}
+
+func (dh DetailHandler) Sponsorship(*SponsorshipEvent) {
+	dh["sponsorship"]++
+}
+
+func (dh DetailHandler) Star(*StarEvent) {
+	dh["star"]++
+}
+
 func (dh DetailHandler) Status(*StatusEvent) {
 	dh["status"]++
 }
 
+func (dh DetailHandler) Team(*TeamEvent) {
+	dh["team"]++
+}
+
 func (dh DetailHandler) TeamAdd(*TeamAddEvent) {
 	dh["team_add"]++
 }
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ChangedPaths gives the sorted list of paths, which were added, modified or
+// removed by the commits of the push. Each path is listed once, even if it
+// was changed by multiple commits.
+//
+// If the payload does not carry the commits, the paths are read from the head
+// commit instead.
+func (e *PushEvent) ChangedPaths() []string {
+	commits := e.Commits
+	if len(commits) == 0 {
+		commits = []PushCommit{e.HeadCommit}
+	}
+	seen := make(map[string]struct{})
+	for _, c := range commits {
+		for _, paths := range [...][]string{c.Added, c.Modified, c.Removed} {
+			for _, p := range paths {
+				seen[p] = struct{}{}
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// MatchPath reports whether the slash-separated file path matches the pattern.
+// Each element of the pattern is matched with the path.Match function, apart
+// from the "**" one, which matches zero or more path elements, e.g.:
+//
+//   services/api/**     matches services/api/main.go and services/api/v1/types.go
+//   **/*.md             matches README.md and docs/index.md
+//   services/*/Makefile matches services/api/Makefile
+//
+// The returned error is path.ErrBadPattern, if the pattern is malformed.
+func MatchPath(pattern, name string) (bool, error) {
+	elems := strings.Split(pattern, "/")
+	if err := validElems(elems); err != nil {
+		return false, err
+	}
+	return matchElems(elems, strings.Split(name, "/")), nil
+}
+
+func validElems(pattern []string) error {
+	for _, elem := range pattern {
+		if _, err := path.Match(elem, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchElems(pattern, name []string) bool {
+	for len(pattern) != 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchElems(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+type pathRoute struct {
+	pattern string
+	fn      func(*PushEvent)
+}
+
+// PathMux is a webhook service, which routes push events to functions
+// registered for the glob patterns matching the changed paths. It can be
+// passed directly to New, or its Push method can be called by other service's
+// Push method.
+//
+// See MatchPath for the description of the pattern syntax.
+type PathMux struct {
+	mu     sync.RWMutex
+	routes []pathRoute
+}
+
+// NewPathMux creates new, empty PathMux.
+func NewPathMux() *PathMux {
+	return &PathMux{}
+}
+
+// Handle registers the fn for handling push events, which changed at least
+// one path matching the pattern. It panics if the pattern is malformed.
+func (m *PathMux) Handle(pattern string, fn func(*PushEvent)) {
+	if err := validElems(strings.Split(pattern, "/")); err != nil {
+		panic("webhook: invalid path pattern " + pattern + ": " + err.Error())
+	}
+	if fn == nil {
+		panic("webhook: nil function for path pattern " + pattern)
+	}
+	m.mu.Lock()
+	m.routes = append(m.routes, pathRoute{pattern: pattern, fn: fn})
+	m.mu.Unlock()
+}
+
+// Push dispatches the event to each of the functions, which pattern matches
+// one of the changed paths. The functions are called in the order they were
+// registered, each of them at most once per event.
+func (m *PathMux) Push(e *PushEvent) {
+	paths := e.ChangedPaths()
+	m.mu.RLock()
+	routes := m.routes
+	m.mu.RUnlock()
+	for _, r := range routes {
+		for _, p := range paths {
+			if ok, _ := MatchPath(r.pattern, p); ok {
+				r.fn(e)
+				break
+			}
+		}
+	}
+}
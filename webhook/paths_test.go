@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestChangedPaths(t *testing.T) {
+	var e PushEvent
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "push.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	cases := [...]struct {
+		event *PushEvent
+		paths []string
+	}{{
+		&e,
+		[]string{"README.md"},
+	}, {
+		&PushEvent{
+			Commits: []PushCommit{
+				{Added: []string{"services/api/main.go"}, Modified: []string{"go.mod"}},
+				{Modified: []string{"services/api/main.go", "docs/index.md"}},
+				{Removed: []string{"go.mod", "services/web/app.js"}},
+			},
+		},
+		[]string{"docs/index.md", "go.mod", "services/api/main.go", "services/web/app.js"},
+	}, {
+		&PushEvent{HeadCommit: PushCommit{Added: []string{"b"}, Removed: []string{"a"}}},
+		[]string{"a", "b"},
+	}, {
+		&PushEvent{},
+		nil,
+	}}
+	for i, cas := range cases {
+		if paths := cas.event.ChangedPaths(); !reflect.DeepEqual(paths, cas.paths) {
+			t.Errorf("want ChangedPaths()=%v; got %v (i=%d)", cas.paths, paths, i)
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	cases := [...]struct {
+		pattern string
+		name    string
+		ok      bool
+	}{
+		{"services/api/**", "services/api/main.go", true},
+		{"services/api/**", "services/api/v1/types.go", true},
+		{"services/api/**", "services/apis/main.go", false},
+		{"services/api/**", "services/api", true},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/guide/index.md", true},
+		{"**/*.md", "docs/index.go", false},
+		{"services/*/Makefile", "services/api/Makefile", true},
+		{"services/*/Makefile", "services/api/v1/Makefile", false},
+		{"services/**/Makefile", "services/api/v1/Makefile", true},
+		{"docs/*.md", "docs/index.md", true},
+		{"docs/*.md", "docs/guide/index.md", false},
+		{"**", "any/path", true},
+		{"go.mod", "go.mod", true},
+		{"go.mod", "sub/go.mod", false},
+	}
+	for i, cas := range cases {
+		ok, err := MatchPath(cas.pattern, cas.name)
+		if err != nil {
+			t.Errorf("MatchPath()=%v (i=%d)", err, i)
+			continue
+		}
+		if ok != cas.ok {
+			t.Errorf("want MatchPath(%q, %q)=%t; got %t (i=%d)", cas.pattern, cas.name, cas.ok, ok, i)
+		}
+	}
+	if _, err := MatchPath("a/[", "a/b"); err == nil {
+		t.Error("want MatchPath() to fail on malformed pattern")
+	}
+}
+
+func TestPathMux(t *testing.T) {
+	got := make(map[string]int)
+	record := func(name string) func(*PushEvent) {
+		return func(*PushEvent) { got[name]++ }
+	}
+	mux := NewPathMux()
+	mux.Handle("services/api/**", record("api"))
+	mux.Handle("services/web/**", record("web"))
+	mux.Handle("**/*.md", record("docs"))
+	e := &PushEvent{
+		Commits: []PushCommit{
+			{Added: []string{"services/api/main.go", "services/api/handler.go"}},
+			{Modified: []string{"services/api/main.go", "README.md"}},
+		},
+	}
+	mux.Push(e)
+	if want := map[string]int{"api": 1, "docs": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v; got %v", want, got)
+	}
+	h := New("secret", mux)
+	if _, ok := h.method["push"]; !ok {
+		t.Error("want PathMux to handle push events")
+	}
+}
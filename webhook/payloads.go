@@ -5,9 +5,11 @@ package webhook
 import "reflect"
 
 var payloads = payloadsMap{
+	"branch_protection_rule":      reflect.TypeOf((*BranchProtectionRuleEvent)(nil)).Elem(),
 	"commit_comment":              reflect.TypeOf((*CommitCommentEvent)(nil)).Elem(),
 	"create":                      reflect.TypeOf((*CreateEvent)(nil)).Elem(),
 	"delete":                      reflect.TypeOf((*DeleteEvent)(nil)).Elem(),
+	"deploy_key":                  reflect.TypeOf((*DeployKeyEvent)(nil)).Elem(),
 	"deployment":                  reflect.TypeOf((*DeploymentEvent)(nil)).Elem(),
 	"deployment_status":           reflect.TypeOf((*DeploymentStatusEvent)(nil)).Elem(),
 	"download":                    reflect.TypeOf((*DownloadEvent)(nil)).Elem(),
@@ -18,93 +20,79 @@ var payloads = payloadsMap{
 	"gollum":                      reflect.TypeOf((*GollumEvent)(nil)).Elem(),
 	"issue_comment":               reflect.TypeOf((*IssueCommentEvent)(nil)).Elem(),
 	"issues":                      reflect.TypeOf((*IssuesEvent)(nil)).Elem(),
+	"label":                       reflect.TypeOf((*LabelEvent)(nil)).Elem(),
 	"member":                      reflect.TypeOf((*MemberEvent)(nil)).Elem(),
 	"membership":                  reflect.TypeOf((*MembershipEvent)(nil)).Elem(),
+	"merge_group":                 reflect.TypeOf((*MergeGroupEvent)(nil)).Elem(),
+	"meta":                        reflect.TypeOf((*MetaEvent)(nil)).Elem(),
+	"org_block":                   reflect.TypeOf((*OrgBlockEvent)(nil)).Elem(),
+	"organization":                reflect.TypeOf((*OrganizationEvent)(nil)).Elem(),
+	"package":                     reflect.TypeOf((*PackageEvent)(nil)).Elem(),
 	"page_build":                  reflect.TypeOf((*PageBuildEvent)(nil)).Elem(),
 	"ping":                        reflect.TypeOf((*PingEvent)(nil)).Elem(),
 	"public":                      reflect.TypeOf((*PublicEvent)(nil)).Elem(),
 	"pull_request":                reflect.TypeOf((*PullRequestEvent)(nil)).Elem(),
 	"pull_request_review_comment": reflect.TypeOf((*PullRequestReviewCommentEvent)(nil)).Elem(),
-	"push":       reflect.TypeOf((*PushEvent)(nil)).Elem(),
-	"release":    reflect.TypeOf((*ReleaseEvent)(nil)).Elem(),
-	"repository": reflect.TypeOf((*RepositoryEvent)(nil)).Elem(),
-	"status":     reflect.TypeOf((*StatusEvent)(nil)).Elem(),
-	"team_add":   reflect.TypeOf((*TeamAddEvent)(nil)).Elem(),
-	"watch":      reflect.TypeOf((*WatchEvent)(nil)).Elem(),
+	"pull_request_review":         reflect.TypeOf((*PullRequestReviewEvent)(nil)).Elem(),
+	"push":                        reflect.TypeOf((*PushEvent)(nil)).Elem(),
+	"registry_package":            reflect.TypeOf((*RegistryPackageEvent)(nil)).Elem(),
+	"release":                     reflect.TypeOf((*ReleaseEvent)(nil)).Elem(),
+	"repository_dispatch":         reflect.TypeOf((*RepositoryDispatchEvent)(nil)).Elem(),
+	"repository":                  reflect.TypeOf((*RepositoryEvent)(nil)).Elem(),
+	"repository_import":           reflect.TypeOf((*RepositoryImportEvent)(nil)).Elem(),
+	"sponsorship":                 reflect.TypeOf((*SponsorshipEvent)(nil)).Elem(),
+	"star":                        reflect.TypeOf((*StarEvent)(nil)).Elem(),
+	"status":                      reflect.TypeOf((*StatusEvent)(nil)).Elem(),
+	"team_add":                    reflect.TypeOf((*TeamAddEvent)(nil)).Elem(),
+	"team":                        reflect.TypeOf((*TeamEvent)(nil)).Elem(),
+	"watch":                       reflect.TypeOf((*WatchEvent)(nil)).Elem(),
 }
 
 // Assets was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Assets struct {
-	BrowserDownloadURL string   `json:"browser_download_url"`
-	ContentType        string   `json:"content_type"`
-	CreatedAt          Time     `json:"created_at"`
-	DownloadCount      int      `json:"download_count"`
-	ID                 int      `json:"id"`
-	Label              string   `json:"label"`
-	Name               string   `json:"name"`
-	Size               int      `json:"size"`
-	State              string   `json:"state"`
-	URL                string   `json:"url"`
-	UpdatedAt          Time     `json:"updated_at"`
-	Uploader           Uploader `json:"uploader"`
-}
-
-// Assignee was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type Assignee struct {
-	AvatarURL         string `json:"avatar_url"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+	BrowserDownloadURL string  `json:"browser_download_url"`
+	ContentType        string  `json:"content_type"`
+	CreatedAt          Time    `json:"created_at"`
+	DownloadCount      int64   `json:"download_count"`
+	ID                 int64   `json:"id"`
+	Label              *string `json:"label"`
+	Name               string  `json:"name"`
+	Size               int64   `json:"size"`
+	State              string  `json:"state"`
+	URL                string  `json:"url"`
+	UpdatedAt          Time    `json:"updated_at"`
+	Uploader           User    `json:"uploader"`
 }
 
-// Author was autogenerated by go generate. To see more details about this
+// AutoMerge was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type Author struct {
-	AvatarURL         string `json:"avatar_url"`
-	Email             string `json:"email"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	Name              string `json:"name"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
-	Username          string `json:"username"`
+type AutoMerge struct {
+	CommitMessage string `json:"commit_message"`
+	CommitTitle   string `json:"commit_title"`
+	EnabledBy     User   `json:"enabled_by"`
+	MergeMethod   string `json:"merge_method"`
 }
 
 // Base was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Base struct {
-	Label string `json:"label"`
-	Ref   string `json:"ref"`
-	Repo  Repo   `json:"repo"`
-	SHA   string `json:"sha"`
-	User  User   `json:"user"`
+	Label string     `json:"label"`
+	Ref   string     `json:"ref"`
+	Repo  Repository `json:"repo"`
+	SHA   string     `json:"sha"`
+	User  User       `json:"user"`
+}
+
+// BranchProtectionRuleEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type BranchProtectionRuleEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	Repository Repository `json:"repository"`
+	Rule       Rule       `json:"rule"`
+	Sender     User       `json:"sender"`
 }
 
 // Branches was autogenerated by go generate. To see more details about this
@@ -121,7 +109,7 @@ type Build struct {
 	CreatedAt Time   `json:"created_at"`
 	Duration  int    `json:"duration"`
 	Error     Error  `json:"error"`
-	Pusher    Pusher `json:"pusher"`
+	Pusher    User   `json:"pusher"`
 	Status    string `json:"status"`
 	URL       string `json:"url"`
 	UpdatedAt Time   `json:"updated_at"`
@@ -130,38 +118,38 @@ type Build struct {
 // ChangeStatus was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type ChangeStatus struct {
-	Additions int `json:"additions"`
-	Deletions int `json:"deletions"`
-	Total     int `json:"total"`
+	Additions int64 `json:"additions"`
+	Deletions int64 `json:"deletions"`
+	Total     int64 `json:"total"`
 }
 
 // Comment was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Comment struct {
-	Body             string `json:"body"`
-	CommitID         string `json:"commit_id"`
-	CreatedAt        Time   `json:"created_at"`
-	DiffHunk         string `json:"diff_hunk"`
-	HTMLURL          string `json:"html_url"`
-	ID               int    `json:"id"`
-	IssueURL         string `json:"issue_url"`
-	Line             int    `json:"line"`
-	OriginalCommitID string `json:"original_commit_id"`
-	OriginalPosition int    `json:"original_position"`
-	Path             string `json:"path"`
-	Position         int    `json:"position"`
-	PullRequestURL   string `json:"pull_request_url"`
-	URL              string `json:"url"`
-	UpdatedAt        Time   `json:"updated_at"`
-	User             User   `json:"user"`
+	Body             string  `json:"body"`
+	CommitID         string  `json:"commit_id"`
+	CreatedAt        Time    `json:"created_at"`
+	DiffHunk         string  `json:"diff_hunk"`
+	HTMLURL          string  `json:"html_url"`
+	ID               int64   `json:"id"`
+	IssueURL         string  `json:"issue_url"`
+	Line             *int    `json:"line"`
+	OriginalCommitID string  `json:"original_commit_id"`
+	OriginalPosition int     `json:"original_position"`
+	Path             *string `json:"path"`
+	Position         *int    `json:"position"`
+	PullRequestURL   string  `json:"pull_request_url"`
+	URL              string  `json:"url"`
+	UpdatedAt        Time    `json:"updated_at"`
+	User             User    `json:"user"`
 }
 
 // Commit was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Commit struct {
-	Author      Author    `json:"author"`
+	Author      User      `json:"author"`
 	CommentsURL string    `json:"comments_url"`
-	Committer   Committer `json:"committer"`
+	Committer   User      `json:"committer"`
 	HTMLURL     string    `json:"html_url"`
 	Parents     []Parents `json:"parents"`
 	SHA         string    `json:"sha"`
@@ -171,111 +159,67 @@ type Commit struct {
 // CommitCommentEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type CommitCommentEvent struct {
+	Common
+
 	Action     string     `json:"action"`
 	Comment    Comment    `json:"comment"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
-}
-
-// Commits was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type Commits struct {
-	Added     []string  `json:"added"`
-	Author    Author    `json:"author"`
-	Committer Committer `json:"committer"`
-	Distinct  bool      `json:"distinct"`
-	ID        string    `json:"id"`
-	Message   string    `json:"message"`
-	Modified  []string  `json:"modified"`
-	Removed   []string  `json:"removed"`
-	Timestamp Time      `json:"timestamp"`
-	URL       string    `json:"url"`
-}
-
-// Committer was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type Committer struct {
-	AvatarURL         string `json:"avatar_url"`
-	Email             string `json:"email"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	Name              string `json:"name"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
-	Username          string `json:"username"`
+	Sender     User       `json:"sender"`
 }
 
 // Config was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Config struct {
 	ContentType string `json:"content_type"`
+	InsecureSsl string `json:"insecure_ssl"`
 	URL         string `json:"url"`
 }
 
 // CreateEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type CreateEvent struct {
+	Common
+
 	Description  string     `json:"description"`
 	MasterBranch string     `json:"master_branch"`
 	PusherType   string     `json:"pusher_type"`
 	Ref          string     `json:"ref"`
 	RefType      string     `json:"ref_type"`
 	Repository   Repository `json:"repository"`
-	Sender       Sender     `json:"sender"`
-}
-
-// Creator was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type Creator struct {
-	AvatarURL         string `json:"avatar_url"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+	Sender       User       `json:"sender"`
 }
 
 // DeleteEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type DeleteEvent struct {
+	Common
+
 	PusherType string     `json:"pusher_type"`
 	Ref        string     `json:"ref"`
 	RefType    string     `json:"ref_type"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
+}
+
+// DeployKeyEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type DeployKeyEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	Key        Key        `json:"key"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
 }
 
 // Deployment was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Deployment struct {
 	CreatedAt     Time    `json:"created_at"`
-	Creator       Creator `json:"creator"`
-	Description   string  `json:"description"`
+	Creator       User    `json:"creator"`
+	Description   *string `json:"description"`
 	Environment   string  `json:"environment"`
-	ID            int     `json:"id"`
+	ID            int64   `json:"id"`
 	Payload       Payload `json:"payload"`
 	Ref           string  `json:"ref"`
 	RepositoryURL string  `json:"repository_url"`
@@ -289,22 +233,24 @@ type Deployment struct {
 // DeploymentEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type DeploymentEvent struct {
+	Common
+
 	Deployment Deployment `json:"deployment"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
 }
 
 // DeploymentStatus was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type DeploymentStatus struct {
 	CreatedAt     Time    `json:"created_at"`
-	Creator       Creator `json:"creator"`
+	Creator       User    `json:"creator"`
 	DeploymentURL string  `json:"deployment_url"`
-	Description   string  `json:"description"`
-	ID            int     `json:"id"`
+	Description   *string `json:"description"`
+	ID            int64   `json:"id"`
 	RepositoryURL string  `json:"repository_url"`
 	State         string  `json:"state"`
-	TargetURL     string  `json:"target_url"`
+	TargetURL     *string `json:"target_url"`
 	URL           string  `json:"url"`
 	UpdatedAt     Time    `json:"updated_at"`
 }
@@ -312,35 +258,54 @@ type DeploymentStatus struct {
 // DeploymentStatusEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type DeploymentStatusEvent struct {
+	Common
+
 	Deployment       Deployment       `json:"deployment"`
 	DeploymentStatus DeploymentStatus `json:"deployment_status"`
 	Repository       Repository       `json:"repository"`
-	Sender           Sender           `json:"sender"`
+	Sender           User             `json:"sender"`
 }
 
 // DownloadEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type DownloadEvent struct {
+	Common
+
 	ContentType   string `json:"content_type"`
 	Description   string `json:"description"`
-	DownloadCount int    `json:"download_count"`
+	DownloadCount int64  `json:"download_count"`
 	HTMLURL       string `json:"html_url"`
-	ID            int    `json:"id"`
+	ID            int64  `json:"id"`
 	Name          string `json:"name"`
-	Size          int    `json:"size"`
+	Size          int64  `json:"size"`
 	URL           string `json:"url"`
 }
 
+// Enterprise was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Enterprise struct {
+	AvatarURL   string `json:"avatar_url"`
+	CreatedAt   Time   `json:"created_at"`
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	NodeID      string `json:"node_id"`
+	Slug        string `json:"slug"`
+	UpdatedAt   Time   `json:"updated_at"`
+	WebsiteURL  string `json:"website_url"`
+}
+
 // Error was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Error struct {
-	Message string `json:"message"`
+	Message *string `json:"message"`
 }
 
 // File was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type File struct {
-	Size      int    `json:"size"`
+	Size      int64  `json:"size"`
 	RawURL    string `json:"raw_url"`
 	Type      string `json:"type"`
 	Truncated bool   `json:"truncated"`
@@ -350,6 +315,8 @@ type File struct {
 // FollowEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type FollowEvent struct {
+	Common
+
 	AvatarURL         string `json:"avatar_url"`
 	Bio               string `json:"bio"`
 	Blog              string `json:"blog"`
@@ -357,21 +324,21 @@ type FollowEvent struct {
 	CreatedAt         Time   `json:"created_at"`
 	Email             string `json:"email"`
 	EventsURL         string `json:"events_url"`
-	Followers         int    `json:"followers"`
+	Followers         int64  `json:"followers"`
 	FollowersURL      string `json:"followers_url"`
-	Following         int    `json:"following"`
+	Following         int64  `json:"following"`
 	FollowingURL      string `json:"following_url"`
 	GistsURL          string `json:"gists_url"`
 	GravatarID        string `json:"gravatar_id"`
 	HTMLURL           string `json:"html_url"`
 	Hireable          bool   `json:"hireable"`
-	ID                int    `json:"id"`
+	ID                int64  `json:"id"`
 	Location          string `json:"location"`
 	Login             string `json:"login"`
 	Name              string `json:"name"`
 	OrganizationsURL  string `json:"organizations_url"`
-	PublicGists       int    `json:"public_gists"`
-	PublicRepos       int    `json:"public_repos"`
+	PublicGists       int64  `json:"public_gists"`
+	PublicRepos       int64  `json:"public_repos"`
 	ReceivedEventsURL string `json:"received_events_url"`
 	ReposURL          string `json:"repos_url"`
 	SiteAdmin         bool   `json:"site_admin"`
@@ -385,6 +352,8 @@ type FollowEvent struct {
 // ForkApplyEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type ForkApplyEvent struct {
+	Common
+
 	After  string `json:"after"`
 	Before string `json:"before"`
 	Head   string `json:"head"`
@@ -393,82 +362,11 @@ type ForkApplyEvent struct {
 // ForkEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type ForkEvent struct {
-	Forkee     Forkee     `json:"forkee"`
+	Common
+
+	Forkee     Repository `json:"forkee"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
-}
-
-// Forkee was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type Forkee struct {
-	ArchiveURL       string `json:"archive_url"`
-	AssigneesURL     string `json:"assignees_url"`
-	BlobsURL         string `json:"blobs_url"`
-	BranchesURL      string `json:"branches_url"`
-	CloneURL         string `json:"clone_url"`
-	CollaboratorsURL string `json:"collaborators_url"`
-	CommentsURL      string `json:"comments_url"`
-	CommitsURL       string `json:"commits_url"`
-	CompareURL       string `json:"compare_url"`
-	ContentsURL      string `json:"contents_url"`
-	ContributorsURL  string `json:"contributors_url"`
-	CreatedAt        Time   `json:"created_at"`
-	DefaultBranch    string `json:"default_branch"`
-	Description      string `json:"description"`
-	DownloadsURL     string `json:"downloads_url"`
-	EventsURL        string `json:"events_url"`
-	Fork             bool   `json:"fork"`
-	Forks            int    `json:"forks"`
-	ForksCount       int    `json:"forks_count"`
-	ForksURL         string `json:"forks_url"`
-	FullName         string `json:"full_name"`
-	GitCommitsURL    string `json:"git_commits_url"`
-	GitRefsURL       string `json:"git_refs_url"`
-	GitTagsURL       string `json:"git_tags_url"`
-	GitURL           string `json:"git_url"`
-	HTMLURL          string `json:"html_url"`
-	HasDownloads     bool   `json:"has_downloads"`
-	HasIssues        bool   `json:"has_issues"`
-	HasPages         bool   `json:"has_pages"`
-	HasWiki          bool   `json:"has_wiki"`
-	Homepage         string `json:"homepage"`
-	HooksURL         string `json:"hooks_url"`
-	ID               int    `json:"id"`
-	IssueCommentURL  string `json:"issue_comment_url"`
-	IssueEventsURL   string `json:"issue_events_url"`
-	IssuesURL        string `json:"issues_url"`
-	KeysURL          string `json:"keys_url"`
-	LabelsURL        string `json:"labels_url"`
-	Language         string `json:"language"`
-	LanguagesURL     string `json:"languages_url"`
-	MergesURL        string `json:"merges_url"`
-	MilestonesURL    string `json:"milestones_url"`
-	MirrorURL        string `json:"mirror_url"`
-	Name             string `json:"name"`
-	NotificationsURL string `json:"notifications_url"`
-	OpenIssues       int    `json:"open_issues"`
-	OpenIssuesCount  int    `json:"open_issues_count"`
-	Owner            Owner  `json:"owner"`
-	Private          bool   `json:"private"`
-	Public           bool   `json:"public"`
-	PullsURL         string `json:"pulls_url"`
-	PushedAt         Time   `json:"pushed_at"`
-	ReleasesURL      string `json:"releases_url"`
-	SSHURL           string `json:"ssh_url"`
-	Size             int    `json:"size"`
-	StargazersCount  int    `json:"stargazers_count"`
-	StargazersURL    string `json:"stargazers_url"`
-	StatusesURL      string `json:"statuses_url"`
-	SubscribersURL   string `json:"subscribers_url"`
-	SubscriptionURL  string `json:"subscription_url"`
-	SvnURL           string `json:"svn_url"`
-	TagsURL          string `json:"tags_url"`
-	TeamsURL         string `json:"teams_url"`
-	TreesURL         string `json:"trees_url"`
-	URL              string `json:"url"`
-	UpdatedAt        Time   `json:"updated_at"`
-	Watchers         int    `json:"watchers"`
-	WatchersCount    int    `json:"watchers_count"`
+	Sender     User       `json:"sender"`
 }
 
 // Forks was autogenerated by go generate. To see more details about this
@@ -484,7 +382,7 @@ type Forks struct {
 // Gist was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Gist struct {
-	Comments    int       `json:"comments"`
+	Comments    int64     `json:"comments"`
 	CommentsURL string    `json:"comments_url"`
 	CommitsURL  string    `json:"commits_url"`
 	CreatedAt   Time      `json:"created_at"`
@@ -497,16 +395,18 @@ type Gist struct {
 	HTMLURL     string    `json:"html_url"`
 	History     []History `json:"history"`
 	ID          string    `json:"id"`
-	Owner       Owner     `json:"owner"`
+	Owner       User      `json:"owner"`
 	Public      bool      `json:"public"`
 	URL         string    `json:"url"`
 	UpdatedAt   Time      `json:"updated_at"`
-	User        User      `json:"user"`
+	User        *User     `json:"user"`
 }
 
 // GistEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type GistEvent struct {
+	Common
+
 	Action string `json:"action"`
 	Gist   Gist   `json:"gist"`
 }
@@ -514,34 +414,21 @@ type GistEvent struct {
 // GollumEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type GollumEvent struct {
+	Common
+
 	Pages      []Pages    `json:"pages"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
 }
 
 // Head was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Head struct {
-	Label string `json:"label"`
-	Ref   string `json:"ref"`
-	Repo  Repo   `json:"repo"`
-	SHA   string `json:"sha"`
-	User  User   `json:"user"`
-}
-
-// HeadCommit was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type HeadCommit struct {
-	Added     []string  `json:"added"`
-	Author    Author    `json:"author"`
-	Committer Committer `json:"committer"`
-	Distinct  bool      `json:"distinct"`
-	ID        string    `json:"id"`
-	Message   string    `json:"message"`
-	Modified  []string  `json:"modified"`
-	Removed   []string  `json:"removed"`
-	Timestamp Time      `json:"timestamp"`
-	URL       string    `json:"url"`
+	Label string     `json:"label"`
+	Ref   string     `json:"ref"`
+	Repo  Repository `json:"repo"`
+	SHA   string     `json:"sha"`
+	User  User       `json:"user"`
 }
 
 // History was autogenerated by go generate. To see more details about this
@@ -561,176 +448,376 @@ type Hook struct {
 	Config    Config   `json:"config"`
 	CreatedAt Time     `json:"created_at"`
 	Events    []string `json:"events"`
-	ID        int      `json:"id"`
+	ID        int64    `json:"id"`
 	Name      string   `json:"name"`
 	PingURL   string   `json:"ping_url"`
 	TestURL   string   `json:"test_url"`
+	Type      string   `json:"type"`
 	URL       string   `json:"url"`
 	UpdatedAt Time     `json:"updated_at"`
 }
 
+// Installation was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Installation struct {
+	ID     int64  `json:"id"`
+	NodeID string `json:"node_id"`
+}
+
 // Issue was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Issue struct {
-	Assignee    User      `json:"assignee"`
-	Body        string    `json:"body"`
-	ClosedAt    Time      `json:"closed_at"`
-	Comments    int       `json:"comments"`
-	CommentsURL string    `json:"comments_url"`
-	CreatedAt   Time      `json:"created_at"`
-	EventsURL   string    `json:"events_url"`
-	HTMLURL     string    `json:"html_url"`
-	ID          int       `json:"id"`
-	Labels      []Labels  `json:"labels"`
-	LabelsURL   string    `json:"labels_url"`
-	Locked      bool      `json:"locked"`
-	Milestone   Milestone `json:"milestone"`
-	Number      int       `json:"number"`
-	State       string    `json:"state"`
-	Title       string    `json:"title"`
-	URL         string    `json:"url"`
-	UpdatedAt   Time      `json:"updated_at"`
-	User        User      `json:"user"`
+	Assignee    *User      `json:"assignee"`
+	Body        string     `json:"body"`
+	ClosedAt    Time       `json:"closed_at"`
+	Comments    int64      `json:"comments"`
+	CommentsURL string     `json:"comments_url"`
+	CreatedAt   Time       `json:"created_at"`
+	EventsURL   string     `json:"events_url"`
+	HTMLURL     string     `json:"html_url"`
+	ID          int64      `json:"id"`
+	Labels      []Labels   `json:"labels"`
+	LabelsURL   string     `json:"labels_url"`
+	Locked      bool       `json:"locked"`
+	Milestone   *Milestone `json:"milestone"`
+	Number      int        `json:"number"`
+	State       string     `json:"state"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	UpdatedAt   Time       `json:"updated_at"`
+	User        User       `json:"user"`
+}
+
+// IssueCommentChanges was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type IssueCommentChanges struct {
+	Body *IssueCommentChangesBody `json:"body"`
+}
+
+// IssueCommentChangesBody was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type IssueCommentChangesBody struct {
+	From string `json:"from"`
 }
 
 // IssueCommentEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type IssueCommentEvent struct {
-	Action     string     `json:"action"`
-	Comment    Comment    `json:"comment"`
-	Issue      Issue      `json:"issue"`
-	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Common
+
+	Action     string               `json:"action"`
+	Changes    *IssueCommentChanges `json:"changes"`
+	Comment    Comment              `json:"comment"`
+	Issue      Issue                `json:"issue"`
+	Repository Repository           `json:"repository"`
+	Sender     User                 `json:"sender"`
+}
+
+// IssuesChanges was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type IssuesChanges struct {
+	Body  *IssuesChangesBody  `json:"body"`
+	Title *IssuesChangesTitle `json:"title"`
+}
+
+// IssuesChangesBody was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type IssuesChangesBody struct {
+	From string `json:"from"`
+}
+
+// IssuesChangesTitle was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type IssuesChangesTitle struct {
+	From string `json:"from"`
 }
 
 // IssuesEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type IssuesEvent struct {
-	Action     string     `json:"action"`
-	Issue      Issue      `json:"issue"`
-	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Common
+
+	Action     string         `json:"action"`
+	Changes    *IssuesChanges `json:"changes"`
+	Issue      Issue          `json:"issue"`
+	Repository Repository     `json:"repository"`
+	Sender     User           `json:"sender"`
+}
+
+// Key was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Key struct {
+	CreatedAt Time   `json:"created_at"`
+	ID        int64  `json:"id"`
+	Key       string `json:"key"`
+	ReadOnly  bool   `json:"read_only"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Verified  bool   `json:"verified"`
+}
+
+// Label was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Label struct {
+	Color       string `json:"color"`
+	Default     bool   `json:"default"`
+	Description string `json:"description"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	NodeID      string `json:"node_id"`
+	URL         string `json:"url"`
+}
+
+// LabelChanges was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type LabelChanges struct {
+	Color *LabelChangesColor `json:"color"`
+	Name  *LabelChangesName  `json:"name"`
+}
+
+// LabelChangesColor was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type LabelChangesColor struct {
+	From string `json:"from"`
+}
+
+// LabelChangesName was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type LabelChangesName struct {
+	From string `json:"from"`
+}
+
+// LabelEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type LabelEvent struct {
+	Common
+
+	Action     string        `json:"action"`
+	Changes    *LabelChanges `json:"changes"`
+	Label      Label         `json:"label"`
+	Repository Repository    `json:"repository"`
+	Sender     User          `json:"sender"`
 }
 
 // Labels was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Labels struct {
-	Color string `json:"color"`
-	Name  string `json:"name"`
-	URL   string `json:"url"`
+	Color       string `json:"color"`
+	Default     bool   `json:"default"`
+	Description string `json:"description"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	NodeID      string `json:"node_id"`
+	URL         string `json:"url"`
 }
 
-// Member was autogenerated by go generate. To see more details about this
+// License was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type Member struct {
-	AvatarURL         string `json:"avatar_url"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+type License struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	NodeID string `json:"node_id"`
+	SpdxID string `json:"spdx_id"`
+	URL    string `json:"url"`
 }
 
 // MemberEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type MemberEvent struct {
+	Common
+
 	Action     string     `json:"action"`
-	Member     Member     `json:"member"`
+	Member     User       `json:"member"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
+}
+
+// Membership was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Membership struct {
+	OrganizationURL string `json:"organization_url"`
+	Role            string `json:"role"`
+	State           string `json:"state"`
+	URL             string `json:"url"`
+	User            User   `json:"user"`
 }
 
 // MembershipEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type MembershipEvent struct {
-	Action       string       `json:"action"`
-	Member       Member       `json:"member"`
-	Organization Organization `json:"organization"`
-	Scope        string       `json:"scope"`
-	Sender       Sender       `json:"sender"`
-	Team         Team         `json:"team"`
+	Common
+
+	Action string `json:"action"`
+	Member User   `json:"member"`
+	Scope  string `json:"scope"`
+	Sender User   `json:"sender"`
+	Team   Team   `json:"team"`
+}
+
+// MergeGroup was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type MergeGroup struct {
+	BaseRef    string     `json:"base_ref"`
+	BaseSHA    string     `json:"base_sha"`
+	HeadCommit PushCommit `json:"head_commit"`
+	HeadRef    string     `json:"head_ref"`
+	HeadSHA    string     `json:"head_sha"`
+}
+
+// MergeGroupEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type MergeGroupEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	MergeGroup MergeGroup `json:"merge_group"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+}
+
+// MetaEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type MetaEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	Hook       Hook       `json:"hook"`
+	HookID     int64      `json:"hook_id"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
 }
 
 // Milestone was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Milestone struct {
-	Creator     Creator `json:"creator"`
-	Description string  `json:"description"`
-	HTMLURL     string  `json:"html_url"`
-	ID          int     `json:"id"`
-	LabelsURL   string  `json:"labels_url"`
-	Number      int     `json:"number"`
-	Title       string  `json:"title"`
-	URL         string  `json:"url"`
+	Creator     User   `json:"creator"`
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+	ID          int64  `json:"id"`
+	LabelsURL   string `json:"labels_url"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+}
+
+// OrgBlockEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type OrgBlockEvent struct {
+	Common
+
+	Action      string `json:"action"`
+	BlockedUser User   `json:"blocked_user"`
+	Sender      User   `json:"sender"`
 }
 
 // Organization was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Organization struct {
-	AvatarURL        string `json:"avatar_url"`
-	Description      string `json:"description"`
-	EventsURL        string `json:"events_url"`
-	ID               int    `json:"id"`
-	Login            string `json:"login"`
-	MembersURL       string `json:"members_url"`
-	PublicMembersURL string `json:"public_members_url"`
-	ReposURL         string `json:"repos_url"`
-	URL              string `json:"url"`
+	AvatarURL        string  `json:"avatar_url"`
+	Description      *string `json:"description"`
+	EventsURL        string  `json:"events_url"`
+	ID               int64   `json:"id"`
+	Login            string  `json:"login"`
+	MembersURL       string  `json:"members_url"`
+	PublicMembersURL string  `json:"public_members_url"`
+	ReposURL         string  `json:"repos_url"`
+	URL              string  `json:"url"`
 }
 
-// Owner was autogenerated by go generate. To see more details about this
+// OrganizationEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type Owner struct {
-	AvatarURL         string `json:"avatar_url"`
-	Email             string `json:"email"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	Name              string `json:"name"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+type OrganizationEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	Membership Membership `json:"membership"`
+	Sender     User       `json:"sender"`
+}
+
+// Package was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Package struct {
+	CreatedAt      Time           `json:"created_at"`
+	Description    string         `json:"description"`
+	Ecosystem      string         `json:"ecosystem"`
+	HTMLURL        string         `json:"html_url"`
+	ID             int64          `json:"id"`
+	Name           string         `json:"name"`
+	Namespace      string         `json:"namespace"`
+	Owner          User           `json:"owner"`
+	PackageType    string         `json:"package_type"`
+	PackageVersion PackageVersion `json:"package_version"`
+	Registry       Registry       `json:"registry"`
+	UpdatedAt      Time           `json:"updated_at"`
+}
+
+// PackageEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PackageEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	Package    Package    `json:"package"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+}
+
+// PackageFiles was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PackageFiles struct {
+	ContentType string `json:"content_type"`
+	CreatedAt   Time   `json:"created_at"`
+	DownloadURL string `json:"download_url"`
+	ID          int64  `json:"id"`
+	Md5         string `json:"md5"`
+	Name        string `json:"name"`
+	SHA1        string `json:"sha1"`
+	SHA256      string `json:"sha256"`
+	Size        int64  `json:"size"`
+	State       string `json:"state"`
+	UpdatedAt   Time   `json:"updated_at"`
+}
+
+// PackageVersion was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PackageVersion struct {
+	Body                string         `json:"body"`
+	CreatedAt           Time           `json:"created_at"`
+	Description         string         `json:"description"`
+	HTMLURL             string         `json:"html_url"`
+	ID                  int64          `json:"id"`
+	InstallationCommand string         `json:"installation_command"`
+	Metadata            []string       `json:"metadata"`
+	Name                string         `json:"name"`
+	PackageFiles        []PackageFiles `json:"package_files"`
+	PackageURL          string         `json:"package_url"`
+	Summary             string         `json:"summary"`
+	TargetCommitish     string         `json:"target_commitish"`
+	TargetOid           string         `json:"target_oid"`
+	UpdatedAt           Time           `json:"updated_at"`
+	Version             string         `json:"version"`
 }
 
 // PageBuildEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type PageBuildEvent struct {
+	Common
+
 	Build      Build      `json:"build"`
-	ID         int        `json:"id"`
+	ID         int64      `json:"id"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
 }
 
 // Pages was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Pages struct {
-	Action   string `json:"action"`
-	HTMLURL  string `json:"html_url"`
-	PageName string `json:"page_name"`
-	SHA      string `json:"sha"`
-	Summary  string `json:"summary"`
-	Title    string `json:"title"`
+	Action   string  `json:"action"`
+	HTMLURL  string  `json:"html_url"`
+	PageName string  `json:"page_name"`
+	SHA      string  `json:"sha"`
+	Summary  *string `json:"summary"`
+	Title    string  `json:"title"`
 }
 
 // Parents was autogenerated by go generate. To see more details about this
@@ -749,120 +836,223 @@ type Payload struct {
 // PingEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type PingEvent struct {
+	Common
+
 	Hook   Hook   `json:"hook"`
-	HookID int    `json:"hook_id"`
+	HookID int64  `json:"hook_id"`
 	Zen    string `json:"zen"`
 }
 
 // PublicEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type PublicEvent struct {
+	Common
+
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
 }
 
 // PullRequest was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type PullRequest struct {
-	Additions         int       `json:"additions"`
-	Assignee          User      `json:"assignee"`
-	Base              Base      `json:"base"`
-	Body              string    `json:"body"`
-	ChangedFiles      int       `json:"changed_files"`
-	ClosedAt          Time      `json:"closed_at"`
-	Comments          int       `json:"comments"`
-	CommentsURL       string    `json:"comments_url"`
-	Commits           int       `json:"commits"`
-	CommitsURL        string    `json:"commits_url"`
-	CreatedAt         Time      `json:"created_at"`
-	Deletions         int       `json:"deletions"`
-	DiffURL           string    `json:"diff_url"`
-	HTMLURL           string    `json:"html_url"`
-	Head              Head      `json:"head"`
-	ID                int       `json:"id"`
-	IssueURL          string    `json:"issue_url"`
-	Locked            bool      `json:"locked"`
-	MergeCommitSHA    string    `json:"merge_commit_sha"`
-	Mergeable         bool      `json:"mergeable"`
-	MergeableState    string    `json:"mergeable_state"`
-	Merged            bool      `json:"merged"`
-	MergedAt          Time      `json:"merged_at"`
-	MergedBy          User      `json:"merged_by"`
-	Milestone         Milestone `json:"milestone"`
-	Number            int       `json:"number"`
-	PatchURL          string    `json:"patch_url"`
-	ReviewCommentURL  string    `json:"review_comment_url"`
-	ReviewComments    int       `json:"review_comments"`
-	ReviewCommentsURL string    `json:"review_comments_url"`
-	State             string    `json:"state"`
-	StatusesURL       string    `json:"statuses_url"`
-	Title             string    `json:"title"`
-	URL               string    `json:"url"`
-	UpdatedAt         Time      `json:"updated_at"`
-	User              User      `json:"user"`
+	ActiveLockReason    *string          `json:"active_lock_reason"`
+	Additions           int64            `json:"additions"`
+	Assignee            *User            `json:"assignee"`
+	Assignees           []User           `json:"assignees"`
+	AuthorAssociation   string           `json:"author_association"`
+	AutoMerge           *AutoMerge       `json:"auto_merge"`
+	Base                Base             `json:"base"`
+	Body                string           `json:"body"`
+	ChangedFiles        int64            `json:"changed_files"`
+	ClosedAt            Time             `json:"closed_at"`
+	Comments            int64            `json:"comments"`
+	CommentsURL         string           `json:"comments_url"`
+	Commits             int64            `json:"commits"`
+	CommitsURL          string           `json:"commits_url"`
+	CreatedAt           Time             `json:"created_at"`
+	Deletions           int64            `json:"deletions"`
+	DiffURL             string           `json:"diff_url"`
+	Draft               bool             `json:"draft"`
+	HTMLURL             string           `json:"html_url"`
+	Head                Head             `json:"head"`
+	ID                  int64            `json:"id"`
+	IssueURL            string           `json:"issue_url"`
+	Labels              []Labels         `json:"labels"`
+	Locked              bool             `json:"locked"`
+	MaintainerCanModify bool             `json:"maintainer_can_modify"`
+	MergeCommitSHA      *string          `json:"merge_commit_sha"`
+	Mergeable           *bool            `json:"mergeable"`
+	MergeableState      string           `json:"mergeable_state"`
+	Merged              bool             `json:"merged"`
+	MergedAt            Time             `json:"merged_at"`
+	MergedBy            *User            `json:"merged_by"`
+	Milestone           *Milestone       `json:"milestone"`
+	NodeID              string           `json:"node_id"`
+	Number              int              `json:"number"`
+	PatchURL            string           `json:"patch_url"`
+	Rebaseable          *bool            `json:"rebaseable"`
+	RequestedReviewers  []User           `json:"requested_reviewers"`
+	RequestedTeams      []RequestedTeams `json:"requested_teams"`
+	ReviewCommentURL    string           `json:"review_comment_url"`
+	ReviewComments      int64            `json:"review_comments"`
+	ReviewCommentsURL   string           `json:"review_comments_url"`
+	State               string           `json:"state"`
+	StatusesURL         string           `json:"statuses_url"`
+	Title               string           `json:"title"`
+	URL                 string           `json:"url"`
+	UpdatedAt           Time             `json:"updated_at"`
+	User                User             `json:"user"`
+}
+
+// PullRequestChanges was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PullRequestChanges struct {
+	Base  *PullRequestChangesBase  `json:"base"`
+	Body  *PullRequestChangesBody  `json:"body"`
+	Title *PullRequestChangesTitle `json:"title"`
+}
+
+// PullRequestChangesBase was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PullRequestChangesBase struct {
+	Ref *PullRequestChangesBaseRef `json:"ref"`
+	SHA *PullRequestChangesBaseSHA `json:"sha"`
+}
+
+// PullRequestChangesBaseRef was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PullRequestChangesBaseRef struct {
+	From string `json:"from"`
+}
+
+// PullRequestChangesBaseSHA was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PullRequestChangesBaseSHA struct {
+	From string `json:"from"`
+}
+
+// PullRequestChangesBody was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PullRequestChangesBody struct {
+	From string `json:"from"`
+}
+
+// PullRequestChangesTitle was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PullRequestChangesTitle struct {
+	From string `json:"from"`
 }
 
 // PullRequestEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type PullRequestEvent struct {
-	Action      string      `json:"action"`
-	Assignee    Assignee    `json:"assignee"`
-	Number      int         `json:"number"`
-	PullRequest PullRequest `json:"pull_request"`
-	Repository  Repository  `json:"repository"`
-	Sender      Sender      `json:"sender"`
+	Common
+
+	Action      string              `json:"action"`
+	Assignee    User                `json:"assignee"`
+	Changes     *PullRequestChanges `json:"changes"`
+	Number      int                 `json:"number"`
+	PullRequest PullRequest         `json:"pull_request"`
+	Repository  Repository          `json:"repository"`
+	Sender      User                `json:"sender"`
 }
 
 // PullRequestReviewCommentEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type PullRequestReviewCommentEvent struct {
+	Common
+
 	Action      string      `json:"action"`
 	Comment     Comment     `json:"comment"`
 	PullRequest PullRequest `json:"pull_request"`
 	Repository  Repository  `json:"repository"`
-	Sender      Sender      `json:"sender"`
+	Sender      User        `json:"sender"`
 }
 
-// PushEvent was autogenerated by go generate. To see more details about this
+// PullRequestReviewEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type PushEvent struct {
-	After      string     `json:"after"`
-	BaseRef    string     `json:"base_ref"`
-	Before     string     `json:"before"`
-	Commits    []Commits  `json:"commits"`
-	Compare    string     `json:"compare"`
-	Created    bool       `json:"created"`
-	Deleted    bool       `json:"deleted"`
-	Forced     bool       `json:"forced"`
-	HeadCommit HeadCommit `json:"head_commit"`
-	Pusher     Pusher     `json:"pusher"`
-	Ref        string     `json:"ref"`
-	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
-}
+type PullRequestReviewEvent struct {
+	Common
 
-// Pusher was autogenerated by go generate. To see more details about this
+	Action      string      `json:"action"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+	Review      Review      `json:"review"`
+	Sender      User        `json:"sender"`
+}
+
+// PushCommit was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type PushCommit struct {
+	Added     []string `json:"added"`
+	Author    User     `json:"author"`
+	Committer User     `json:"committer"`
+	Distinct  bool     `json:"distinct"`
+	ID        string   `json:"id"`
+	Message   string   `json:"message"`
+	Modified  []string `json:"modified"`
+	Removed   []string `json:"removed"`
+	Timestamp Time     `json:"timestamp"`
+	TreeID    string   `json:"tree_id"`
+	URL       string   `json:"url"`
+}
+
+// PushEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type Pusher struct {
-	AvatarURL         string `json:"avatar_url"`
-	Email             string `json:"email"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	Name              string `json:"name"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+type PushEvent struct {
+	Common
+
+	After      string       `json:"after"`
+	BaseRef    *string      `json:"base_ref"`
+	Before     string       `json:"before"`
+	Commits    []PushCommit `json:"commits"`
+	Compare    string       `json:"compare"`
+	Created    bool         `json:"created"`
+	Deleted    bool         `json:"deleted"`
+	Forced     bool         `json:"forced"`
+	HeadCommit PushCommit   `json:"head_commit"`
+	Pusher     User         `json:"pusher"`
+	Ref        string       `json:"ref"`
+	Repository Repository   `json:"repository"`
+	Sender     User         `json:"sender"`
+}
+
+// Registry was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Registry struct {
+	AboutURL string `json:"about_url"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	URL      string `json:"url"`
+	Vendor   string `json:"vendor"`
+}
+
+// RegistryPackage was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RegistryPackage struct {
+	CreatedAt      Time           `json:"created_at"`
+	Description    string         `json:"description"`
+	Ecosystem      string         `json:"ecosystem"`
+	HTMLURL        string         `json:"html_url"`
+	ID             int64          `json:"id"`
+	Name           string         `json:"name"`
+	Namespace      string         `json:"namespace"`
+	Owner          User           `json:"owner"`
+	PackageType    string         `json:"package_type"`
+	PackageVersion PackageVersion `json:"package_version"`
+	Registry       Registry       `json:"registry"`
+	UpdatedAt      Time           `json:"updated_at"`
+}
+
+// RegistryPackageEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RegistryPackageEvent struct {
+	Common
+
+	Action          string          `json:"action"`
+	RegistryPackage RegistryPackage `json:"registry_package"`
+	Repository      Repository      `json:"repository"`
+	Sender          User            `json:"sender"`
 }
 
 // Release was autogenerated by go generate. To see more details about this
@@ -870,13 +1060,13 @@ type Pusher struct {
 type Release struct {
 	Assets          []Assets `json:"assets"`
 	AssetsURL       string   `json:"assets_url"`
-	Author          Author   `json:"author"`
-	Body            string   `json:"body"`
+	Author          User     `json:"author"`
+	Body            *string  `json:"body"`
 	CreatedAt       Time     `json:"created_at"`
 	Draft           bool     `json:"draft"`
 	HTMLURL         string   `json:"html_url"`
-	ID              int      `json:"id"`
-	Name            string   `json:"name"`
+	ID              int64    `json:"id"`
+	Name            *string  `json:"name"`
 	Prerelease      bool     `json:"prerelease"`
 	PublishedAt     Time     `json:"published_at"`
 	TagName         string   `json:"tag_name"`
@@ -890,204 +1080,270 @@ type Release struct {
 // ReleaseEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type ReleaseEvent struct {
+	Common
+
 	Action     string     `json:"action"`
 	Release    Release    `json:"release"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
-}
-
-// Repo was autogenerated by go generate. To see more details about this
-// payload type visit https://developer.github.com/v3/activity/events/types.
-type Repo struct {
-	ArchiveURL       string `json:"archive_url"`
-	AssigneesURL     string `json:"assignees_url"`
-	BlobsURL         string `json:"blobs_url"`
-	BranchesURL      string `json:"branches_url"`
-	CloneURL         string `json:"clone_url"`
-	CollaboratorsURL string `json:"collaborators_url"`
-	CommentsURL      string `json:"comments_url"`
-	CommitsURL       string `json:"commits_url"`
-	CompareURL       string `json:"compare_url"`
-	ContentsURL      string `json:"contents_url"`
-	ContributorsURL  string `json:"contributors_url"`
-	CreatedAt        Time   `json:"created_at"`
-	DefaultBranch    string `json:"default_branch"`
-	Description      string `json:"description"`
-	DownloadsURL     string `json:"downloads_url"`
-	EventsURL        string `json:"events_url"`
-	Fork             bool   `json:"fork"`
-	Forks            int    `json:"forks"`
-	ForksCount       int    `json:"forks_count"`
-	ForksURL         string `json:"forks_url"`
-	FullName         string `json:"full_name"`
-	GitCommitsURL    string `json:"git_commits_url"`
-	GitRefsURL       string `json:"git_refs_url"`
-	GitTagsURL       string `json:"git_tags_url"`
-	GitURL           string `json:"git_url"`
-	HTMLURL          string `json:"html_url"`
-	HasDownloads     bool   `json:"has_downloads"`
-	HasIssues        bool   `json:"has_issues"`
-	HasPages         bool   `json:"has_pages"`
-	HasWiki          bool   `json:"has_wiki"`
-	Homepage         string `json:"homepage"`
-	HooksURL         string `json:"hooks_url"`
-	ID               int    `json:"id"`
-	IssueCommentURL  string `json:"issue_comment_url"`
-	IssueEventsURL   string `json:"issue_events_url"`
-	IssuesURL        string `json:"issues_url"`
-	KeysURL          string `json:"keys_url"`
-	LabelsURL        string `json:"labels_url"`
-	Language         string `json:"language"`
-	LanguagesURL     string `json:"languages_url"`
-	MergesURL        string `json:"merges_url"`
-	MilestonesURL    string `json:"milestones_url"`
-	MirrorURL        string `json:"mirror_url"`
-	Name             string `json:"name"`
-	NotificationsURL string `json:"notifications_url"`
-	OpenIssues       int    `json:"open_issues"`
-	OpenIssuesCount  int    `json:"open_issues_count"`
-	Owner            Owner  `json:"owner"`
-	Private          bool   `json:"private"`
-	PullsURL         string `json:"pulls_url"`
-	PushedAt         Time   `json:"pushed_at"`
-	ReleasesURL      string `json:"releases_url"`
-	SSHURL           string `json:"ssh_url"`
-	Size             int    `json:"size"`
-	StargazersCount  int    `json:"stargazers_count"`
-	StargazersURL    string `json:"stargazers_url"`
-	StatusesURL      string `json:"statuses_url"`
-	SubscribersURL   string `json:"subscribers_url"`
-	SubscriptionURL  string `json:"subscription_url"`
-	SvnURL           string `json:"svn_url"`
-	TagsURL          string `json:"tags_url"`
-	TeamsURL         string `json:"teams_url"`
-	TreesURL         string `json:"trees_url"`
-	URL              string `json:"url"`
-	UpdatedAt        Time   `json:"updated_at"`
-	Watchers         int    `json:"watchers"`
-	WatchersCount    int    `json:"watchers_count"`
+	Sender     User       `json:"sender"`
 }
 
 // Repository was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Repository struct {
-	ArchiveURL       string `json:"archive_url"`
-	AssigneesURL     string `json:"assignees_url"`
-	BlobsURL         string `json:"blobs_url"`
-	BranchesURL      string `json:"branches_url"`
-	CloneURL         string `json:"clone_url"`
-	CollaboratorsURL string `json:"collaborators_url"`
-	CommentsURL      string `json:"comments_url"`
-	CommitsURL       string `json:"commits_url"`
-	CompareURL       string `json:"compare_url"`
-	ContentsURL      string `json:"contents_url"`
-	ContributorsURL  string `json:"contributors_url"`
-	CreatedAt        Time   `json:"created_at"`
-	DefaultBranch    string `json:"default_branch"`
-	Description      string `json:"description"`
-	DownloadsURL     string `json:"downloads_url"`
-	EventsURL        string `json:"events_url"`
-	Fork             bool   `json:"fork"`
-	Forks            int    `json:"forks"`
-	ForksCount       int    `json:"forks_count"`
-	ForksURL         string `json:"forks_url"`
-	FullName         string `json:"full_name"`
-	GitCommitsURL    string `json:"git_commits_url"`
-	GitRefsURL       string `json:"git_refs_url"`
-	GitTagsURL       string `json:"git_tags_url"`
-	GitURL           string `json:"git_url"`
-	HTMLURL          string `json:"html_url"`
-	HasDownloads     bool   `json:"has_downloads"`
-	HasIssues        bool   `json:"has_issues"`
-	HasPages         bool   `json:"has_pages"`
-	HasWiki          bool   `json:"has_wiki"`
-	Homepage         string `json:"homepage"`
-	HooksURL         string `json:"hooks_url"`
-	ID               int    `json:"id"`
-	IssueCommentURL  string `json:"issue_comment_url"`
-	IssueEventsURL   string `json:"issue_events_url"`
-	IssuesURL        string `json:"issues_url"`
-	KeysURL          string `json:"keys_url"`
-	LabelsURL        string `json:"labels_url"`
-	Language         string `json:"language"`
-	LanguagesURL     string `json:"languages_url"`
-	MasterBranch     string `json:"master_branch"`
-	MergesURL        string `json:"merges_url"`
-	MilestonesURL    string `json:"milestones_url"`
-	MirrorURL        string `json:"mirror_url"`
-	Name             string `json:"name"`
-	NotificationsURL string `json:"notifications_url"`
-	OpenIssues       int    `json:"open_issues"`
-	OpenIssuesCount  int    `json:"open_issues_count"`
-	Owner            Owner  `json:"owner"`
-	Private          bool   `json:"private"`
-	PullsURL         string `json:"pulls_url"`
-	PushedAt         Time   `json:"pushed_at"`
-	ReleasesURL      string `json:"releases_url"`
-	SSHURL           string `json:"ssh_url"`
-	Size             int    `json:"size"`
-	Stargazers       int    `json:"stargazers"`
-	StargazersCount  int    `json:"stargazers_count"`
-	StargazersURL    string `json:"stargazers_url"`
-	StatusesURL      string `json:"statuses_url"`
-	SubscribersURL   string `json:"subscribers_url"`
-	SubscriptionURL  string `json:"subscription_url"`
-	SvnURL           string `json:"svn_url"`
-	TagsURL          string `json:"tags_url"`
-	TeamsURL         string `json:"teams_url"`
-	TreesURL         string `json:"trees_url"`
-	URL              string `json:"url"`
-	UpdatedAt        Time   `json:"updated_at"`
-	Watchers         int    `json:"watchers"`
-	WatchersCount    int    `json:"watchers_count"`
+	AllowAutoMerge            bool     `json:"allow_auto_merge"`
+	AllowForking              bool     `json:"allow_forking"`
+	AllowMergeCommit          bool     `json:"allow_merge_commit"`
+	AllowRebaseMerge          bool     `json:"allow_rebase_merge"`
+	AllowSquashMerge          bool     `json:"allow_squash_merge"`
+	AllowUpdateBranch         bool     `json:"allow_update_branch"`
+	ArchiveURL                string   `json:"archive_url"`
+	Archived                  bool     `json:"archived"`
+	AssigneesURL              string   `json:"assignees_url"`
+	BlobsURL                  string   `json:"blobs_url"`
+	BranchesURL               string   `json:"branches_url"`
+	CloneURL                  string   `json:"clone_url"`
+	CollaboratorsURL          string   `json:"collaborators_url"`
+	CommentsURL               string   `json:"comments_url"`
+	CommitsURL                string   `json:"commits_url"`
+	CompareURL                string   `json:"compare_url"`
+	ContentsURL               string   `json:"contents_url"`
+	ContributorsURL           string   `json:"contributors_url"`
+	CreatedAt                 Time     `json:"created_at"`
+	DefaultBranch             string   `json:"default_branch"`
+	DeleteBranchOnMerge       bool     `json:"delete_branch_on_merge"`
+	Description               string   `json:"description"`
+	Disabled                  bool     `json:"disabled"`
+	DownloadsURL              string   `json:"downloads_url"`
+	EventsURL                 string   `json:"events_url"`
+	Fork                      bool     `json:"fork"`
+	Forks                     int64    `json:"forks"`
+	ForksCount                int64    `json:"forks_count"`
+	ForksURL                  string   `json:"forks_url"`
+	FullName                  string   `json:"full_name"`
+	GitCommitsURL             string   `json:"git_commits_url"`
+	GitRefsURL                string   `json:"git_refs_url"`
+	GitTagsURL                string   `json:"git_tags_url"`
+	GitURL                    string   `json:"git_url"`
+	HTMLURL                   string   `json:"html_url"`
+	HasDownloads              bool     `json:"has_downloads"`
+	HasIssues                 bool     `json:"has_issues"`
+	HasPages                  bool     `json:"has_pages"`
+	HasWiki                   bool     `json:"has_wiki"`
+	Homepage                  *string  `json:"homepage"`
+	HooksURL                  string   `json:"hooks_url"`
+	ID                        int64    `json:"id"`
+	IsTemplate                bool     `json:"is_template"`
+	IssueCommentURL           string   `json:"issue_comment_url"`
+	IssueEventsURL            string   `json:"issue_events_url"`
+	IssuesURL                 string   `json:"issues_url"`
+	KeysURL                   string   `json:"keys_url"`
+	LabelsURL                 string   `json:"labels_url"`
+	Language                  *string  `json:"language"`
+	LanguagesURL              string   `json:"languages_url"`
+	License                   *License `json:"license"`
+	MasterBranch              string   `json:"master_branch"`
+	MergeCommitMessage        string   `json:"merge_commit_message"`
+	MergeCommitTitle          string   `json:"merge_commit_title"`
+	MergesURL                 string   `json:"merges_url"`
+	MilestonesURL             string   `json:"milestones_url"`
+	MirrorURL                 *string  `json:"mirror_url"`
+	Name                      string   `json:"name"`
+	NodeID                    string   `json:"node_id"`
+	NotificationsURL          string   `json:"notifications_url"`
+	OpenIssues                int64    `json:"open_issues"`
+	OpenIssuesCount           int64    `json:"open_issues_count"`
+	Owner                     User     `json:"owner"`
+	Private                   bool     `json:"private"`
+	Public                    bool     `json:"public"`
+	PullsURL                  string   `json:"pulls_url"`
+	PushedAt                  Time     `json:"pushed_at"`
+	ReleasesURL               string   `json:"releases_url"`
+	SSHURL                    string   `json:"ssh_url"`
+	Size                      int64    `json:"size"`
+	SquashMergeCommitMessage  string   `json:"squash_merge_commit_message"`
+	SquashMergeCommitTitle    string   `json:"squash_merge_commit_title"`
+	Stargazers                int64    `json:"stargazers"`
+	StargazersCount           int64    `json:"stargazers_count"`
+	StargazersURL             string   `json:"stargazers_url"`
+	StatusesURL               string   `json:"statuses_url"`
+	SubscribersURL            string   `json:"subscribers_url"`
+	SubscriptionURL           string   `json:"subscription_url"`
+	SvnURL                    string   `json:"svn_url"`
+	TagsURL                   string   `json:"tags_url"`
+	TeamsURL                  string   `json:"teams_url"`
+	Topics                    []string `json:"topics"`
+	TreesURL                  string   `json:"trees_url"`
+	URL                       string   `json:"url"`
+	UpdatedAt                 Time     `json:"updated_at"`
+	UseSquashPrTitleAsDefault bool     `json:"use_squash_pr_title_as_default"`
+	Visibility                string   `json:"visibility"`
+	Watchers                  int64    `json:"watchers"`
+	WatchersCount             int64    `json:"watchers_count"`
+	WebCommitSignoffRequired  bool     `json:"web_commit_signoff_required"`
+}
+
+// RepositoryChanges was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RepositoryChanges struct {
+	Repository *RepositoryChangesRepository `json:"repository"`
+}
+
+// RepositoryChangesRepository was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RepositoryChangesRepository struct {
+	Name *RepositoryChangesRepositoryName `json:"name"`
+}
+
+// RepositoryChangesRepositoryName was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RepositoryChangesRepositoryName struct {
+	From string `json:"from"`
+}
+
+// RepositoryDispatchEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RepositoryDispatchEvent struct {
+	Common
+
+	Action        string                 `json:"action"`
+	Branch        string                 `json:"branch"`
+	ClientPayload map[string]interface{} `json:"client_payload"`
+	Repository    Repository             `json:"repository"`
+	Sender        User                   `json:"sender"`
 }
 
 // RepositoryEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type RepositoryEvent struct {
-	Action       string       `json:"action"`
-	Organization Organization `json:"organization"`
-	Repository   Repository   `json:"repository"`
-	Sender       Sender       `json:"sender"`
+	Common
+
+	Action     string             `json:"action"`
+	Changes    *RepositoryChanges `json:"changes"`
+	Repository Repository         `json:"repository"`
+	Sender     User               `json:"sender"`
 }
 
-// Sender was autogenerated by go generate. To see more details about this
+// RepositoryImportEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type Sender struct {
-	AvatarURL         string `json:"avatar_url"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
+type RepositoryImportEvent struct {
+	Common
+
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+	Status     string     `json:"status"`
+}
+
+// RequestedTeams was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type RequestedTeams struct {
+	Description         string `json:"description"`
+	HTMLURL             string `json:"html_url"`
+	ID                  int64  `json:"id"`
+	MembersURL          string `json:"members_url"`
+	Name                string `json:"name"`
+	NodeID              string `json:"node_id"`
+	NotificationSetting string `json:"notification_setting"`
+	Permission          string `json:"permission"`
+	Privacy             string `json:"privacy"`
+	RepositoriesURL     string `json:"repositories_url"`
+	Slug                string `json:"slug"`
+	URL                 string `json:"url"`
+}
+
+// Review was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Review struct {
+	AuthorAssociation string `json:"author_association"`
+	Body              string `json:"body"`
+	CommitID          string `json:"commit_id"`
 	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+	ID                int64  `json:"id"`
+	NodeID            string `json:"node_id"`
+	PullRequestURL    string `json:"pull_request_url"`
+	State             string `json:"state"`
+	SubmittedAt       Time   `json:"submitted_at"`
+	User              User   `json:"user"`
+}
+
+// Rule was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Rule struct {
+	AdminEnforced                            bool     `json:"admin_enforced"`
+	AllowDeletionsEnforcementLevel           string   `json:"allow_deletions_enforcement_level"`
+	AllowForcePushesEnforcementLevel         string   `json:"allow_force_pushes_enforcement_level"`
+	AuthorizedActorNames                     []string `json:"authorized_actor_names"`
+	AuthorizedActorsOnly                     bool     `json:"authorized_actors_only"`
+	AuthorizedDismissalActorsOnly            bool     `json:"authorized_dismissal_actors_only"`
+	CreatedAt                                Time     `json:"created_at"`
+	DismissStaleReviewsOnPush                bool     `json:"dismiss_stale_reviews_on_push"`
+	ID                                       int64    `json:"id"`
+	IgnoreApprovalsFromContributors          bool     `json:"ignore_approvals_from_contributors"`
+	LinearHistoryRequirementEnforcementLevel string   `json:"linear_history_requirement_enforcement_level"`
+	MergeQueueEnforcementLevel               string   `json:"merge_queue_enforcement_level"`
+	Name                                     string   `json:"name"`
+	PullRequestReviewsEnforcementLevel       string   `json:"pull_request_reviews_enforcement_level"`
+	RepositoryID                             int64    `json:"repository_id"`
+	RequireCodeOwnerReview                   bool     `json:"require_code_owner_review"`
+	RequiredApprovingReviewCount             int64    `json:"required_approving_review_count"`
+	RequiredConversationResolutionLevel      string   `json:"required_conversation_resolution_level"`
+	RequiredDeploymentsEnforcementLevel      string   `json:"required_deployments_enforcement_level"`
+	RequiredStatusChecks                     []string `json:"required_status_checks"`
+	RequiredStatusChecksEnforcementLevel     string   `json:"required_status_checks_enforcement_level"`
+	SignatureRequirementEnforcementLevel     string   `json:"signature_requirement_enforcement_level"`
+	StrictRequiredStatusChecksPolicy         bool     `json:"strict_required_status_checks_policy"`
+	UpdatedAt                                Time     `json:"updated_at"`
+}
+
+// Sponsorship was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Sponsorship struct {
+	CreatedAt    Time   `json:"created_at"`
+	NodeID       string `json:"node_id"`
+	PrivacyLevel string `json:"privacy_level"`
+	Sponsor      User   `json:"sponsor"`
+	Sponsorable  User   `json:"sponsorable"`
+	Tier         Tier   `json:"tier"`
+}
+
+// SponsorshipEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type SponsorshipEvent struct {
+	Common
+
+	Action      string      `json:"action"`
+	Sender      User        `json:"sender"`
+	Sponsorship Sponsorship `json:"sponsorship"`
+}
+
+// StarEvent was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type StarEvent struct {
+	Common
+
+	Action     string     `json:"action"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+	StarredAt  Time       `json:"starred_at"`
 }
 
 // StatusEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type StatusEvent struct {
+	Common
+
 	Branches    []Branches `json:"branches"`
 	Commit      Commit     `json:"commit"`
 	Context     string     `json:"context"`
 	CreatedAt   Time       `json:"created_at"`
-	Description string     `json:"description"`
-	ID          int        `json:"id"`
+	Description *string    `json:"description"`
+	ID          int64      `json:"id"`
 	Name        string     `json:"name"`
 	Repository  Repository `json:"repository"`
 	SHA         string     `json:"sha"`
-	Sender      Sender     `json:"sender"`
+	Sender      User       `json:"sender"`
 	State       string     `json:"state"`
-	TargetURL   string     `json:"target_url"`
+	TargetURL   *string    `json:"target_url"`
 	UpdatedAt   Time       `json:"updated_at"`
 }
 
@@ -1095,10 +1351,13 @@ type StatusEvent struct {
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Team struct {
 	Description     string `json:"description"`
-	ID              int    `json:"id"`
+	HTMLURL         string `json:"html_url"`
+	ID              int64  `json:"id"`
 	MembersURL      string `json:"members_url"`
 	Name            string `json:"name"`
+	NodeID          string `json:"node_id"`
 	Permission      string `json:"permission"`
+	Privacy         string `json:"privacy"`
 	RepositoriesURL string `json:"repositories_url"`
 	Slug            string `json:"slug"`
 	URL             string `json:"url"`
@@ -1107,46 +1366,51 @@ type Team struct {
 // TeamAddEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type TeamAddEvent struct {
-	Organization Organization `json:"organization"`
-	Repository   Repository   `json:"repository"`
-	Sender       Sender       `json:"sender"`
-	Team         Team         `json:"team"`
+	Common
+
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+	Team       Team       `json:"team"`
 }
 
-// Uploader was autogenerated by go generate. To see more details about this
+// TeamEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
-type Uploader struct {
-	AvatarURL         string `json:"avatar_url"`
-	EventsURL         string `json:"events_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	GravatarID        string `json:"gravatar_id"`
-	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
-	Login             string `json:"login"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	ReposURL          string `json:"repos_url"`
-	SiteAdmin         bool   `json:"site_admin"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	Type              string `json:"type"`
-	URL               string `json:"url"`
+type TeamEvent struct {
+	Common
+
+	Action string `json:"action"`
+	Sender User   `json:"sender"`
+	Team   Team   `json:"team"`
+}
+
+// Tier was autogenerated by go generate. To see more details about this
+// payload type visit https://developer.github.com/v3/activity/events/types.
+type Tier struct {
+	CreatedAt             Time   `json:"created_at"`
+	Description           string `json:"description"`
+	IsCustomAmount        bool   `json:"is_custom_amount"`
+	IsOneTime             bool   `json:"is_one_time"`
+	MonthlyPriceInCents   int    `json:"monthly_price_in_cents"`
+	MonthlyPriceInDollars int    `json:"monthly_price_in_dollars"`
+	Name                  string `json:"name"`
+	NodeID                string `json:"node_id"`
 }
 
 // User was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type User struct {
 	AvatarURL         string `json:"avatar_url"`
+	Email             string `json:"email"`
 	EventsURL         string `json:"events_url"`
 	FollowersURL      string `json:"followers_url"`
 	FollowingURL      string `json:"following_url"`
 	GistsURL          string `json:"gists_url"`
 	GravatarID        string `json:"gravatar_id"`
 	HTMLURL           string `json:"html_url"`
-	ID                int    `json:"id"`
+	ID                int64  `json:"id"`
 	Login             string `json:"login"`
+	Name              string `json:"name"`
+	NodeID            string `json:"node_id"`
 	OrganizationsURL  string `json:"organizations_url"`
 	ReceivedEventsURL string `json:"received_events_url"`
 	ReposURL          string `json:"repos_url"`
@@ -1155,16 +1419,1001 @@ type User struct {
 	SubscriptionsURL  string `json:"subscriptions_url"`
 	Type              string `json:"type"`
 	URL               string `json:"url"`
+	Username          string `json:"username"`
 }
 
 // WatchEvent was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type WatchEvent struct {
+	Common
+
 	Action     string     `json:"action"`
 	Repository Repository `json:"repository"`
-	Sender     Sender     `json:"sender"`
+	Sender     User       `json:"sender"`
 }
 
 // Files was autogenerated by go generate. To see more details about this
 // payload type visit https://developer.github.com/v3/activity/events/types.
 type Files map[string]File
+
+// Assignee is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Assignee = User
+
+// Author is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Author = User
+
+// Commits is an alias for the PushCommit type, which is shared by
+// the structurally equivalent payload objects.
+type Commits = PushCommit
+
+// Committer is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Committer = User
+
+// Creator is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Creator = User
+
+// Forkee is an alias for the Repository type, which is shared by
+// the structurally equivalent payload objects.
+type Forkee = Repository
+
+// HeadCommit is an alias for the PushCommit type, which is shared by
+// the structurally equivalent payload objects.
+type HeadCommit = PushCommit
+
+// Member is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Member = User
+
+// Owner is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Owner = User
+
+// Pusher is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Pusher = User
+
+// Repo is an alias for the Repository type, which is shared by
+// the structurally equivalent payload objects.
+type Repo = Repository
+
+// Sender is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Sender = User
+
+// Uploader is an alias for the User type, which is shared by
+// the structurally equivalent payload objects.
+type Uploader = User
+
+// EventName implements the Event interface.
+func (e *BranchProtectionRuleEvent) EventName() string {
+	return "branch_protection_rule"
+}
+
+// GetRepository implements the Event interface.
+func (e *BranchProtectionRuleEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *BranchProtectionRuleEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *BranchProtectionRuleEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *CommitCommentEvent) EventName() string {
+	return "commit_comment"
+}
+
+// GetRepository implements the Event interface.
+func (e *CommitCommentEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *CommitCommentEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *CommitCommentEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *CreateEvent) EventName() string {
+	return "create"
+}
+
+// GetRepository implements the Event interface.
+func (e *CreateEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *CreateEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *CreateEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *DeleteEvent) EventName() string {
+	return "delete"
+}
+
+// GetRepository implements the Event interface.
+func (e *DeleteEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *DeleteEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *DeleteEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *DeployKeyEvent) EventName() string {
+	return "deploy_key"
+}
+
+// GetRepository implements the Event interface.
+func (e *DeployKeyEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *DeployKeyEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *DeployKeyEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *DeploymentEvent) EventName() string {
+	return "deployment"
+}
+
+// GetRepository implements the Event interface.
+func (e *DeploymentEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *DeploymentEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *DeploymentEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *DeploymentStatusEvent) EventName() string {
+	return "deployment_status"
+}
+
+// GetRepository implements the Event interface.
+func (e *DeploymentStatusEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *DeploymentStatusEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *DeploymentStatusEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *DownloadEvent) EventName() string {
+	return "download"
+}
+
+// GetRepository implements the Event interface.
+func (e *DownloadEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *DownloadEvent) GetSender() *User {
+	return nil
+}
+
+// GetAction implements the Event interface.
+func (e *DownloadEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *FollowEvent) EventName() string {
+	return "follow"
+}
+
+// GetRepository implements the Event interface.
+func (e *FollowEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *FollowEvent) GetSender() *User {
+	return nil
+}
+
+// GetAction implements the Event interface.
+func (e *FollowEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *ForkApplyEvent) EventName() string {
+	return "fork_apply"
+}
+
+// GetRepository implements the Event interface.
+func (e *ForkApplyEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *ForkApplyEvent) GetSender() *User {
+	return nil
+}
+
+// GetAction implements the Event interface.
+func (e *ForkApplyEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *ForkEvent) EventName() string {
+	return "fork"
+}
+
+// GetRepository implements the Event interface.
+func (e *ForkEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *ForkEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *ForkEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *GistEvent) EventName() string {
+	return "gist"
+}
+
+// GetRepository implements the Event interface.
+func (e *GistEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *GistEvent) GetSender() *User {
+	return nil
+}
+
+// GetAction implements the Event interface.
+func (e *GistEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *GollumEvent) EventName() string {
+	return "gollum"
+}
+
+// GetRepository implements the Event interface.
+func (e *GollumEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *GollumEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *GollumEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *IssueCommentEvent) EventName() string {
+	return "issue_comment"
+}
+
+// GetRepository implements the Event interface.
+func (e *IssueCommentEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *IssueCommentEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *IssueCommentEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *IssuesEvent) EventName() string {
+	return "issues"
+}
+
+// GetRepository implements the Event interface.
+func (e *IssuesEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *IssuesEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *IssuesEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *LabelEvent) EventName() string {
+	return "label"
+}
+
+// GetRepository implements the Event interface.
+func (e *LabelEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *LabelEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *LabelEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *MemberEvent) EventName() string {
+	return "member"
+}
+
+// GetRepository implements the Event interface.
+func (e *MemberEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *MemberEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *MemberEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *MembershipEvent) EventName() string {
+	return "membership"
+}
+
+// GetRepository implements the Event interface.
+func (e *MembershipEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *MembershipEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *MembershipEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *MergeGroupEvent) EventName() string {
+	return "merge_group"
+}
+
+// GetRepository implements the Event interface.
+func (e *MergeGroupEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *MergeGroupEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *MergeGroupEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *MetaEvent) EventName() string {
+	return "meta"
+}
+
+// GetRepository implements the Event interface.
+func (e *MetaEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *MetaEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *MetaEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *OrgBlockEvent) EventName() string {
+	return "org_block"
+}
+
+// GetRepository implements the Event interface.
+func (e *OrgBlockEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *OrgBlockEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *OrgBlockEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *OrganizationEvent) EventName() string {
+	return "organization"
+}
+
+// GetRepository implements the Event interface.
+func (e *OrganizationEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *OrganizationEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *OrganizationEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *PackageEvent) EventName() string {
+	return "package"
+}
+
+// GetRepository implements the Event interface.
+func (e *PackageEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PackageEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PackageEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *PageBuildEvent) EventName() string {
+	return "page_build"
+}
+
+// GetRepository implements the Event interface.
+func (e *PageBuildEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PageBuildEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PageBuildEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *PingEvent) EventName() string {
+	return "ping"
+}
+
+// GetRepository implements the Event interface.
+func (e *PingEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *PingEvent) GetSender() *User {
+	return nil
+}
+
+// GetAction implements the Event interface.
+func (e *PingEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *PublicEvent) EventName() string {
+	return "public"
+}
+
+// GetRepository implements the Event interface.
+func (e *PublicEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PublicEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PublicEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *PullRequestEvent) EventName() string {
+	return "pull_request"
+}
+
+// GetRepository implements the Event interface.
+func (e *PullRequestEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PullRequestEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PullRequestEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *PullRequestReviewCommentEvent) EventName() string {
+	return "pull_request_review_comment"
+}
+
+// GetRepository implements the Event interface.
+func (e *PullRequestReviewCommentEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PullRequestReviewCommentEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PullRequestReviewCommentEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *PullRequestReviewEvent) EventName() string {
+	return "pull_request_review"
+}
+
+// GetRepository implements the Event interface.
+func (e *PullRequestReviewEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PullRequestReviewEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PullRequestReviewEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *PushEvent) EventName() string {
+	return "push"
+}
+
+// GetRepository implements the Event interface.
+func (e *PushEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *PushEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *PushEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *RegistryPackageEvent) EventName() string {
+	return "registry_package"
+}
+
+// GetRepository implements the Event interface.
+func (e *RegistryPackageEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *RegistryPackageEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *RegistryPackageEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *ReleaseEvent) EventName() string {
+	return "release"
+}
+
+// GetRepository implements the Event interface.
+func (e *ReleaseEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *ReleaseEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *ReleaseEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *RepositoryDispatchEvent) EventName() string {
+	return "repository_dispatch"
+}
+
+// GetRepository implements the Event interface.
+func (e *RepositoryDispatchEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *RepositoryDispatchEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *RepositoryDispatchEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *RepositoryEvent) EventName() string {
+	return "repository"
+}
+
+// GetRepository implements the Event interface.
+func (e *RepositoryEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *RepositoryEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *RepositoryEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *RepositoryImportEvent) EventName() string {
+	return "repository_import"
+}
+
+// GetRepository implements the Event interface.
+func (e *RepositoryImportEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *RepositoryImportEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *RepositoryImportEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *SponsorshipEvent) EventName() string {
+	return "sponsorship"
+}
+
+// GetRepository implements the Event interface.
+func (e *SponsorshipEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *SponsorshipEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *SponsorshipEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *StarEvent) EventName() string {
+	return "star"
+}
+
+// GetRepository implements the Event interface.
+func (e *StarEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *StarEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *StarEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *StatusEvent) EventName() string {
+	return "status"
+}
+
+// GetRepository implements the Event interface.
+func (e *StatusEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *StatusEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *StatusEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *TeamAddEvent) EventName() string {
+	return "team_add"
+}
+
+// GetRepository implements the Event interface.
+func (e *TeamAddEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *TeamAddEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *TeamAddEvent) GetAction() string {
+	return ""
+}
+
+// EventName implements the Event interface.
+func (e *TeamEvent) EventName() string {
+	return "team"
+}
+
+// GetRepository implements the Event interface.
+func (e *TeamEvent) GetRepository() *Repository {
+	return nil
+}
+
+// GetSender implements the Event interface.
+func (e *TeamEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *TeamEvent) GetAction() string {
+	return e.Action
+}
+
+// EventName implements the Event interface.
+func (e *WatchEvent) EventName() string {
+	return "watch"
+}
+
+// GetRepository implements the Event interface.
+func (e *WatchEvent) GetRepository() *Repository {
+	return &e.Repository
+}
+
+// GetSender implements the Event interface.
+func (e *WatchEvent) GetSender() *User {
+	return &e.Sender
+}
+
+// GetAction implements the Event interface.
+func (e *WatchEvent) GetAction() string {
+	return e.Action
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *IssueCommentChanges) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Body != nil {
+		fields = append(fields, "body")
+	}
+	return fields
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *IssuesChanges) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Body != nil {
+		fields = append(fields, "body")
+	}
+	if c.Title != nil {
+		fields = append(fields, "title")
+	}
+	return fields
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *LabelChanges) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Color != nil {
+		fields = append(fields, "color")
+	}
+	if c.Name != nil {
+		fields = append(fields, "name")
+	}
+	return fields
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *PullRequestChanges) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Base != nil {
+		for _, f := range c.Base.Fields() {
+			fields = append(fields, "base."+f)
+		}
+	}
+	if c.Body != nil {
+		fields = append(fields, "body")
+	}
+	if c.Title != nil {
+		fields = append(fields, "title")
+	}
+	return fields
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *PullRequestChangesBase) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Ref != nil {
+		fields = append(fields, "ref")
+	}
+	if c.SHA != nil {
+		fields = append(fields, "sha")
+	}
+	return fields
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *RepositoryChanges) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Repository != nil {
+		for _, f := range c.Repository.Fields() {
+			fields = append(fields, "repository."+f)
+		}
+	}
+	return fields
+}
+
+// Fields gives JSON names of the changed members. Names of nested members
+// are joined with a dot, e.g. "base.ref". It returns nil, if c is nil.
+func (c *RepositoryChangesRepository) Fields() []string {
+	if c == nil {
+		return nil
+	}
+	var fields []string
+	if c.Name != nil {
+		fields = append(fields, "name")
+	}
+	return fields
+}
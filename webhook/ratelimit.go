@@ -0,0 +1,314 @@
+package webhook
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var errRateLimit = errors.New("rate limit exceeded")
+
+// Keys the deliveries are rate limited by.
+const (
+	RateByRepository = "repository" // full name of the event's repository
+	RateBySender     = "sender"     // login of the event's sender
+	RateByEvent      = "event"      // name of the event
+)
+
+// RateLimitAction configures what happens to the deliveries, which exceed
+// the rate limit.
+type RateLimitAction int
+
+const (
+	// RateLimitDrop rejects the delivery with 429 Too Many Requests.
+	RateLimitDrop RateLimitAction = iota
+
+	// RateLimitQueue responds with 202 Accepted and delays handling of
+	// the delivery until it is within the limit.
+	RateLimitQueue
+
+	// RateLimitCoalesce responds with 202 Accepted and delays handling of
+	// the delivery like RateLimitQueue, but only the latest of the delayed
+	// deliveries of the same event is handled, the earlier ones are skipped.
+	RateLimitCoalesce
+)
+
+// DefaultMaxQueue is the default maximum number of delayed deliveries per key.
+const DefaultMaxQueue = 100
+
+// RateLimit is a token-bucket rate limit of the deliveries, kept separately
+// for each value of the key, e.g. for each repository. Each delivery takes
+// a token from its bucket, the buckets are refilled at Rate tokens per second
+// up to Burst tokens.
+//
+// The deliveries, which are delayed, are kept in memory. If the Handler
+// uses a Spool, the deliveries exceeding the limit are always rejected.
+type RateLimit struct {
+	// By is the key the deliveries are limited by, one of RateByRepository,
+	// RateBySender or RateByEvent. The deliveries of events, which have
+	// no such member, are not limited.
+	By string
+
+	// Events, if non-empty, limits only the deliveries of the given events.
+	Events []string
+
+	// Rate is the number of deliveries per second allowed for each key.
+	// It must be positive, otherwise the buckets are never refilled and
+	// the deliveries exceeding Burst are rejected regardless of the Action.
+	Rate float64
+
+	// Burst is the maximum number of deliveries allowed at once.
+	// If 0, 1 is used instead.
+	Burst int
+
+	// Action configures the outcome of the deliveries exceeding the limit.
+	Action RateLimitAction
+
+	// MaxQueue is the maximum number of delayed deliveries per key, the ones
+	// above it are rejected. If 0, DefaultMaxQueue is used instead.
+	MaxQueue int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time // last time the full buckets were removed
+}
+
+type bucket struct {
+	tokens  float64
+	last    time.Time
+	pending map[string]*pendingDelivery // coalesced deliveries by event
+}
+
+// pendingDelivery is a delayed delivery, which the later deliveries of
+// the same event can take the place of.
+type pendingDelivery struct {
+	mu   sync.Mutex
+	run  func(http.ResponseWriter)
+	done bool // whether the delivery was handled
+}
+
+// reservation is a token taken for the delivery by a single rate limit.
+type reservation struct {
+	rl      *RateLimit
+	b       *bucket
+	taken   bool             // whether a token was taken
+	wait    time.Duration    // delay of the delivery, if any
+	pending *pendingDelivery // delayed delivery to coalesce with
+}
+
+// key gives the value of the key the delivery is limited by, or an empty
+// string, if the limit does not apply to it.
+func (rl *RateLimit) key(event string, payload interface{}) string {
+	if len(rl.Events) != 0 && !contains(rl.Events, event) {
+		return ""
+	}
+	if rl.By == RateByEvent {
+		return event
+	}
+	e, ok := payload.(Event)
+	if !ok {
+		return ""
+	}
+	switch rl.By {
+	case RateByRepository:
+		if r := e.GetRepository(); r != nil {
+			return r.FullName
+		}
+	case RateBySender:
+		if u := e.GetSender(); u != nil {
+			return u.Login
+		}
+	}
+	return ""
+}
+
+// reserve takes a token for the delivery. If the bucket is empty and delay
+// is true, the token is taken in advance and the reservation gives the delay
+// of the delivery, unless the action is RateLimitDrop. Otherwise the delivery
+// is rejected with errRateLimit. For RateLimitCoalesce no token is taken, if
+// there already is a delayed delivery of the same event.
+//
+// The reservation is always non-nil, for rejected deliveries it gives the time
+// after which the bucket will have a token again.
+func (rl *RateLimit) reserve(key, event string, now time.Time, delay bool) (*reservation, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	burst := float64(rl.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	if rl.buckets == nil {
+		rl.buckets = make(map[string]*bucket)
+	}
+	rl.sweep(now, burst)
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		rl.buckets[key] = b
+	}
+	if rl.Rate > 0 {
+		b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rl.Rate)
+	}
+	b.last = now
+	r := &reservation{rl: rl, b: b}
+	if b.tokens >= 1 {
+		b.tokens--
+		r.taken = true
+		return r, nil
+	}
+	r.wait = rl.wait(b.tokens)
+	if !delay || rl.Action == RateLimitDrop || rl.Rate <= 0 {
+		return r, errRateLimit
+	}
+	if p, ok := b.pending[event]; ok && rl.Action == RateLimitCoalesce {
+		r.pending = p
+		return r, nil
+	}
+	max := rl.MaxQueue
+	if max <= 0 {
+		max = DefaultMaxQueue
+	}
+	if -b.tokens >= float64(max) {
+		return r, errRateLimit
+	}
+	b.tokens--
+	r.taken = true
+	return r, nil
+}
+
+// cancel gives back the token taken by the reservation.
+func (r *reservation) cancel() {
+	if r.taken {
+		r.rl.mu.Lock()
+		r.b.tokens++
+		r.rl.mu.Unlock()
+	}
+}
+
+// sweep removes the buckets, which were refilled and have no pending
+// deliveries, so keys which are no longer active are forgotten. The buckets
+// are swept at most once per time it takes to refill an empty one.
+func (rl *RateLimit) sweep(now time.Time, burst float64) {
+	if rl.Rate <= 0 || now.Sub(rl.swept).Seconds() < burst/rl.Rate {
+		return
+	}
+	rl.swept = now
+	for key, b := range rl.buckets {
+		if len(b.pending) == 0 && b.tokens+now.Sub(b.last).Seconds()*rl.Rate >= burst {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// wait gives the time until the bucket has a token again.
+func (rl *RateLimit) wait(tokens float64) time.Duration {
+	if rl.Rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration((1 - tokens) / rl.Rate * float64(time.Second))
+}
+
+// limit applies the rate limits to the delivery. The tokens are taken from
+// all of the limits, which apply to the delivery, or from none of them, if any
+// of the limits rejects it. The delivery, which exceeds any of the limits, is
+// delayed by the longest of the delays and handled by run - if run is nil, it
+// is rejected instead. If the delivery was scheduled to be handled later,
+// limit reports it.
+func (h *Handler) limit(event string, payload interface{}, run func(http.ResponseWriter)) (bool, time.Duration, error) {
+	var (
+		now  = time.Now()
+		rs   []*reservation
+		wait time.Duration
+	)
+	for _, rl := range h.RateLimits {
+		key := rl.key(event, payload)
+		if key == "" {
+			continue
+		}
+		r, err := rl.reserve(key, event, now, run != nil)
+		if err != nil {
+			for _, r := range rs {
+				r.cancel()
+			}
+			return false, r.wait, err
+		}
+		rs = append(rs, r)
+		if r.wait > wait {
+			wait = r.wait
+		}
+	}
+	if wait == 0 {
+		return false, 0, nil
+	}
+	for _, r := range rs {
+		if r.pending != nil && r.pending.replace(run) {
+			// The delivery took the place of the delayed one,
+			// thus it needs no tokens of its own.
+			for _, r := range rs {
+				r.cancel()
+			}
+			return true, wait, nil
+		}
+	}
+	p := &pendingDelivery{run: run}
+	for _, r := range rs {
+		if r.wait != 0 && r.rl.Action == RateLimitCoalesce {
+			r.rl.mu.Lock()
+			if r.b.pending == nil {
+				r.b.pending = make(map[string]*pendingDelivery)
+			}
+			r.b.pending[event] = p
+			r.rl.mu.Unlock()
+		}
+	}
+	time.AfterFunc(wait, func() {
+		for _, r := range rs {
+			r.rl.mu.Lock()
+			if r.b.pending[event] == p {
+				delete(r.b.pending, event)
+			}
+			r.rl.mu.Unlock()
+		}
+		p.mu.Lock()
+		p.done = true
+		run := p.run
+		p.mu.Unlock()
+		run(&discardWriter{})
+	})
+	return true, wait, nil
+}
+
+// replace makes the delayed delivery handled by run instead, the replaced
+// one is skipped. It reports false, if the delivery was already handled.
+func (p *pendingDelivery) replace(run func(http.ResponseWriter)) bool {
+	p.mu.Lock()
+	if p.done {
+		p.mu.Unlock()
+		return false
+	}
+	skipped := p.run
+	p.run = run
+	p.mu.Unlock()
+	go skipped(nil)
+	return true
+}
+
+// tooMany rejects the delivery, which exceeded the rate limit.
+func (h *Handler) tooMany(w http.ResponseWriter, req *http.Request, wait time.Duration) {
+	if wait < time.Duration(math.MaxInt64) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+	h.fatal(w, req, http.StatusTooManyRequests, errRateLimit)
+}
+
+func contains(s []string, v string) bool {
+	for _, t := range s {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
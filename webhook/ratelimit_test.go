@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitReserve(t *testing.T) {
+	rl := &RateLimit{By: RateByRepository, Rate: 1, Burst: 2}
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if r, err := rl.reserve("a/b", "push", now, false); r.wait != 0 || err != nil {
+			t.Fatalf("want reserve()=0, nil; got %v, %v", r.wait, err)
+		}
+	}
+	r, err := rl.reserve("a/b", "push", now, false)
+	if err != errRateLimit {
+		t.Fatalf("want err=%v; got %v", errRateLimit, err)
+	}
+	if r.wait != time.Second {
+		t.Errorf("want wait=1s; got %v", r.wait)
+	}
+	// Other keys have separate buckets.
+	if _, err := rl.reserve("a/c", "push", now, false); err != nil {
+		t.Fatalf("want reserve()=nil; got %v", err)
+	}
+	// The bucket is refilled after a second.
+	if _, err := rl.reserve("a/b", "push", now.Add(time.Second), false); err != nil {
+		t.Fatalf("want reserve()=nil; got %v", err)
+	}
+}
+
+func TestRateLimitSweep(t *testing.T) {
+	rl := &RateLimit{By: RateBySender, Rate: 1, Burst: 2}
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		rl.reserve(fmt.Sprintf("bot-%d", i), "issue_comment", now, false)
+	}
+	if n := len(rl.buckets); n != 100 {
+		t.Fatalf("want 100 buckets; got %d", n)
+	}
+	// After the buckets were refilled, only the active key is kept.
+	rl.reserve("bot-0", "issue_comment", now.Add(2*time.Second), false)
+	if n := len(rl.buckets); n != 1 {
+		t.Errorf("want 1 bucket; got %d", n)
+	}
+}
+
+func TestRateLimitZeroRate(t *testing.T) {
+	rl := &RateLimit{By: RateBySender, Action: RateLimitQueue}
+	now := time.Now()
+	if r, err := rl.reserve("bot", "issue_comment", now, true); r.wait != 0 || err != nil {
+		t.Fatalf("want reserve()=0, nil; got %v, %v", r.wait, err)
+	}
+	if _, err := rl.reserve("bot", "issue_comment", now, true); err != errRateLimit {
+		t.Fatalf("want reserve()=%v; got %v", errRateLimit, err)
+	}
+}
+
+func TestHandlerRateLimits(t *testing.T) {
+	h := New(secret, BlanketHandler{})
+	h.RateLimits = []*RateLimit{
+		{By: RateByRepository, Rate: 1, Burst: 2},
+		{By: RateBySender, Rate: 1, Action: RateLimitQueue},
+	}
+	payload := func(repo, sender string) interface{} {
+		return &IssueCommentEvent{
+			Repository: Repository{FullName: repo},
+			Sender:     User{Login: sender},
+		}
+	}
+	run := func(http.ResponseWriter) { t.Error("want delivery to be rejected") }
+	if delayed, _, err := h.limit("issue_comment", payload("a/b", "bot"), nil); delayed || err != nil {
+		t.Fatalf("want limit()=false, nil; got %t, %v", delayed, err)
+	}
+	// The sender limit rejects the delivery, the repository one gives
+	// its token back.
+	if _, _, err := h.limit("issue_comment", payload("a/b", "bot"), nil); err != errRateLimit {
+		t.Fatalf("want limit()=%v; got %v", errRateLimit, err)
+	}
+	if _, _, err := h.limit("issue_comment", payload("a/b", "octocat"), nil); err != nil {
+		t.Fatalf("want limit()=nil; got %v", err)
+	}
+	// The delivery delayed by the sender limit is still rejected
+	// by the repository one.
+	h.RateLimits[0].Action = RateLimitDrop
+	if delayed, _, err := h.limit("issue_comment", payload("a/b", "bot"), run); delayed || err != errRateLimit {
+		t.Fatalf("want limit()=false, %v; got %t, %v", errRateLimit, delayed, err)
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	payload := &IssueCommentEvent{
+		Repository: Repository{FullName: "rjeczalik/gh"},
+		Sender:     User{Login: "bot"},
+	}
+	cases := []struct {
+		rl  *RateLimit
+		key string
+	}{
+		{&RateLimit{By: RateByRepository}, "rjeczalik/gh"},
+		{&RateLimit{By: RateBySender}, "bot"},
+		{&RateLimit{By: RateByEvent}, "issue_comment"},
+		{&RateLimit{By: RateBySender, Events: []string{"issue_comment"}}, "bot"},
+		{&RateLimit{By: RateBySender, Events: []string{"push"}}, ""},
+	}
+	for i, cas := range cases {
+		if key := cas.rl.key("issue_comment", payload); key != cas.key {
+			t.Errorf("%d: want key=%q; got %q", i, cas.key, key)
+		}
+	}
+}
+
+// CommentHandler records the bodies of the handled comments.
+type CommentHandler struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (ch *CommentHandler) IssueComment(e *IssueCommentEvent) {
+	ch.mu.Lock()
+	ch.bodies = append(ch.bodies, e.Comment.Body)
+	ch.mu.Unlock()
+}
+
+func TestHandlerRateLimit(t *testing.T) {
+	cases := []struct {
+		action RateLimitAction
+		codes  []int
+		bodies []string
+	}{
+		{RateLimitDrop, []int{200, 429, 429, 429}, []string{"0"}},
+		{RateLimitQueue, []int{200, 202, 202, 202}, []string{"0", "1", "2", "3"}},
+		{RateLimitCoalesce, []int{200, 202, 202, 202}, []string{"0", "3"}},
+	}
+	for i, cas := range cases {
+		ch := &CommentHandler{}
+		h := New(secret, ch)
+		h.RateLimits = []*RateLimit{{
+			By:     RateBySender,
+			Rate:   20,
+			Action: cas.action,
+		}}
+		var codes []int
+		for j := range cas.codes {
+			body := []byte(fmt.Sprintf(`{"comment":{"body":"%d"},"sender":{"login":"bot"}}`, j))
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "issue_comment")
+			req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if j == 0 {
+				h.Wait() // the first delivery is handled asynchronously
+			}
+			codes = append(codes, w.Code)
+			if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "1" {
+				t.Errorf("%d: want Retry-After=1; got %q", i, w.Header().Get("Retry-After"))
+			}
+		}
+		h.Wait()
+		if !reflect.DeepEqual(codes, cas.codes) {
+			t.Errorf("%d: want codes=%v; got %v", i, cas.codes, codes)
+		}
+		if !reflect.DeepEqual(ch.bodies, cas.bodies) {
+			t.Errorf("%d: want bodies=%v; got %v", i, cas.bodies, ch.bodies)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var errRedact = errors.New("payload is not a valid JSON value")
+
+// Anonymizer gives a replacement for the sensitive string value. It uses
+// the Pseudonyms to obtain replacements, which are consistent within
+// the payload.
+type Anonymizer func(value string, p *Pseudonyms) string
+
+// Built-in anonymizers.
+var (
+	// AnonymizeString replaces the value with its pseudonym.
+	AnonymizeString Anonymizer = func(s string, p *Pseudonyms) string {
+		return p.Get(s)
+	}
+
+	// AnonymizeEmail replaces the value with an e-mail address in the example.com
+	// domain, e.g. anon-1f2e3d4c@example.com.
+	AnonymizeEmail Anonymizer = func(s string, p *Pseudonyms) string {
+		if s == "" {
+			return ""
+		}
+		return p.Get(s) + "@example.com"
+	}
+
+	// AnonymizeLogin replaces each slash-separated element of the value with its
+	// pseudonym, so it handles both logins and full names of repositories,
+	// e.g. octocat/Hello-World.
+	AnonymizeLogin Anonymizer = func(s string, p *Pseudonyms) string {
+		elems := strings.Split(s, "/")
+		for i := range elems {
+			elems[i] = p.Get(elems[i])
+		}
+		return strings.Join(elems, "/")
+	}
+
+	// AnonymizeAvatar replaces the value with an avatar URL, which does not
+	// identify the user.
+	AnonymizeAvatar Anonymizer = func(s string, p *Pseudonyms) string {
+		if s == "" || !strings.Contains(s, "://") {
+			return p.Get(s)
+		}
+		return "https://avatars.githubusercontent.com/u/" + p.Get(s)
+	}
+
+	// AnonymizeURL removes credentials and tokens from the URL and replaces
+	// elements of its path, which were anonymized elsewhere in the payload,
+	// with their pseudonyms. The owner and the name of the repository of
+	// the API URLs, e.g. /repos/octocat/Hello-World, are anonymized as well.
+	AnonymizeURL Anonymizer = func(s string, p *Pseudonyms) string {
+		s = userinfo.ReplaceAllString(s, "://")
+		s = token.ReplaceAllString(s, "${1}redacted")
+		if m := reposPath.FindStringSubmatch(s); m != nil {
+			p.Get(m[1])
+			p.Get(m[2])
+		}
+		return p.Replace(s)
+	}
+
+	// Remove replaces the value with an empty string.
+	Remove Anonymizer = func(string, *Pseudonyms) string {
+		return ""
+	}
+)
+
+var (
+	userinfo  = regexp.MustCompile(`://[^/@]*@`)
+	token     = regexp.MustCompile(`(?i)([?&](?:access_token|token|private_token|client_secret|signature|sig|jwt)=)[^&#]*`)
+	reposPath = regexp.MustCompile(`/repos/([^/?#]+)/([^/?#]+)`)
+)
+
+// Anonymizers maps names of the built-in anonymizers to their functions.
+// It is used for reading rules from configuration files.
+var Anonymizers = map[string]Anonymizer{
+	"string": AnonymizeString,
+	"email":  AnonymizeEmail,
+	"login":  AnonymizeLogin,
+	"avatar": AnonymizeAvatar,
+	"url":    AnonymizeURL,
+	"remove": Remove,
+}
+
+// RedactRule redacts string values, which path matches the Path pattern.
+//
+// The path of a value is a dot-separated list of object keys and array
+// indices leading to the value, e.g. commits.0.author.email. Each element of
+// the pattern is matched with the path.Match function, apart from the "**" one,
+// which matches zero or more elements, e.g. "**.email" or "**.*_url".
+//
+// If Requires is non-empty, the rule applies only to the payloads, which have
+// all of the given top-level keys, e.g. "sha" and "context" of status events.
+type RedactRule struct {
+	Path       string
+	Anonymizer Anonymizer
+	Requires   []string
+}
+
+// DefaultRedactRules redact e-mails, names and logins of users, names of repositories,
+// avatars and URLs of a payload.
+var DefaultRedactRules = []RedactRule{
+	{Path: "**.email", Anonymizer: AnonymizeEmail},
+	{Path: "**.login", Anonymizer: AnonymizeLogin},
+	{Path: "**.username", Anonymizer: AnonymizeLogin},
+	{Path: "**.full_name", Anonymizer: AnonymizeLogin},
+	{Path: "**.owner.name", Anonymizer: AnonymizeLogin},
+	{Path: "pusher.name", Anonymizer: AnonymizeLogin},
+	// The top-level name is the full name of the repository in status events
+	// and the name of the user in follow events.
+	{Path: "name", Anonymizer: AnonymizeLogin, Requires: []string{"sha", "context"}},
+	{Path: "name", Anonymizer: AnonymizeString, Requires: []string{"login"}},
+	{Path: "**.authorized_actor_names.*", Anonymizer: AnonymizeLogin},
+	{Path: "**.repository.name", Anonymizer: AnonymizeLogin},
+	{Path: "**.repo.name", Anonymizer: AnonymizeLogin},
+	{Path: "**.author.name", Anonymizer: AnonymizeString},
+	{Path: "**.committer.name", Anonymizer: AnonymizeString},
+	{Path: "**.avatar_url", Anonymizer: AnonymizeAvatar},
+	{Path: "**.gravatar_id", Anonymizer: AnonymizeAvatar},
+	{Path: "**.label", Anonymizer: AnonymizeURL},
+	{Path: "**.url", Anonymizer: AnonymizeURL},
+	{Path: "**.href", Anonymizer: AnonymizeURL},
+	{Path: "**.compare", Anonymizer: AnonymizeURL},
+	{Path: "**.*_url", Anonymizer: AnonymizeURL},
+}
+
+// Redactor rewrites sensitive values of JSON payloads.
+//
+// Only string values are redacted, thus the redacted payload has the same
+// structure as the original one and it can be still used for generating
+// the payload types with cmd/structgen.
+type Redactor struct {
+	// Rules lists the values to redact. For each value the first matching
+	// rule is applied. If nil, DefaultRedactRules are used instead.
+	Rules []RedactRule
+
+	// Key, if non-empty, turns on pseudonymization - each value is replaced
+	// with a pseudonym derived from the value and the key, e.g. anon-1f2e3d4c.
+	// The pseudonyms are consistent across the payloads redacted with
+	// the same key. If empty, each value is replaced with "redacted".
+	Key []byte
+}
+
+// NewRedactor creates new Redactor, which uses DefaultRedactRules and pseudonymizes
+// the values with the given key.
+func NewRedactor(key []byte) *Redactor {
+	return &Redactor{Key: key}
+}
+
+// Validate checks whether the patterns of the rules are well-formed.
+func (r *Redactor) Validate() error {
+	for _, rule := range r.rules() {
+		if err := validElems(strings.Split(rule.Path, ".")); err != nil {
+			return errors.New("invalid rule " + rule.Path + ": " + err.Error())
+		}
+		if rule.Anonymizer == nil {
+			return errors.New("invalid rule " + rule.Path + ": nil anonymizer")
+		}
+	}
+	return nil
+}
+
+// Redact gives a copy of the JSON payload with the sensitive values replaced.
+// The order of the object members is preserved.
+func (r *Redactor) Redact(payload []byte) ([]byte, error) {
+	if !json.Valid(payload) {
+		return nil, errRedact
+	}
+	p := &Pseudonyms{key: r.Key, seen: make(map[string]string)}
+	rules := r.applicable(payload)
+	// The first pass collects the pseudonyms of the whole payload, so they
+	// can be replaced within the URLs regardless of the members' order.
+	if err := rewrite(nil, payload, rules, p); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := rewrite(&buf, payload, rules, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *Redactor) rules() []RedactRule {
+	if r.Rules != nil {
+		return r.Rules
+	}
+	return DefaultRedactRules
+}
+
+// applicable gives the rules, which apply to the payload.
+func (r *Redactor) applicable(payload []byte) []RedactRule {
+	var keys map[string]json.RawMessage
+	json.Unmarshal(payload, &keys) // keys is nil, if payload is not an object
+	var rules []RedactRule
+Rules:
+	for _, rule := range r.rules() {
+		for _, key := range rule.Requires {
+			if _, ok := keys[key]; !ok {
+				continue Rules
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func anonymizer(rules []RedactRule, path []string) Anonymizer {
+	for _, rule := range rules {
+		if matchElems(strings.Split(rule.Path, "."), path) {
+			return rule.Anonymizer
+		}
+	}
+	return nil
+}
+
+// frame is a state of an object or array being rewritten.
+type frame struct {
+	obj   bool   // whether it's an object or array
+	key   bool   // whether the next token is an object's key
+	n     int    // number of the members written so far
+	field string // key of the current object member
+}
+
+func (f *frame) elem() string {
+	if f.obj {
+		return f.field
+	}
+	return strconv.Itoa(f.n)
+}
+
+// rewrite writes the JSON payload to buf with the rules applied. If buf is
+// nil, the rules are applied only in order to collect the pseudonyms.
+func rewrite(buf *bytes.Buffer, payload []byte, rules []RedactRule, p *Pseudonyms) error {
+	if buf == nil {
+		buf = &bytes.Buffer{}
+		defer buf.Reset()
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var (
+		stack []*frame
+		path  []string
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var top *frame
+		if len(stack) != 0 {
+			top = stack[len(stack)-1]
+		}
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			buf.WriteByte(byte(delim))
+			stack, path = stack[:len(stack)-1], path[:len(path)-1]
+			if len(stack) != 0 {
+				stack[len(stack)-1].next()
+			}
+			continue
+		}
+		if top != nil && top.key {
+			if top.n != 0 {
+				buf.WriteByte(',')
+			}
+			top.field, top.key = tok.(string), false
+			writeJSON(buf, top.field)
+			buf.WriteByte(':')
+			continue
+		}
+		if top != nil && !top.obj && top.n != 0 {
+			buf.WriteByte(',')
+		}
+		var elem string
+		if top != nil {
+			elem = top.elem()
+		}
+		switch v := tok.(type) {
+		case json.Delim:
+			buf.WriteByte(byte(v))
+			stack = append(stack, &frame{obj: v == '{', key: v == '{'})
+			path = append(path, elem)
+			continue
+		case string:
+			if top != nil {
+				if anonymize := anonymizer(rules, append(path[1:len(path):len(path)], elem)); anonymize != nil {
+					v = anonymize(v, p)
+				}
+			}
+			writeJSON(buf, v)
+		case json.Number:
+			buf.WriteString(v.String())
+		default:
+			writeJSON(buf, v)
+		}
+		if top != nil {
+			top.next()
+		}
+	}
+}
+
+func (f *frame) next() {
+	f.n++
+	f.key = f.obj
+}
+
+func writeJSON(buf *bytes.Buffer, v interface{}) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	buf.Truncate(buf.Len() - 1) // Encode terminates the value with a newline
+}
+
+// Pseudonyms gives replacements for the sensitive values of a single payload.
+type Pseudonyms struct {
+	key  []byte
+	seen map[string]string
+}
+
+// Get gives a replacement for the value and remembers it, so the value is
+// also replaced within the URLs of the payload. The replacement is either
+// a pseudonym or "redacted", depending on whether the Redactor has a key.
+func (p *Pseudonyms) Get(value string) string {
+	if value == "" {
+		return ""
+	}
+	if s, ok := p.seen[value]; ok {
+		return s
+	}
+	s := "redacted"
+	if len(p.key) != 0 {
+		mac := hmac.New(sha256.New, p.key)
+		io.WriteString(mac, value)
+		s = "anon-" + hex.EncodeToString(mac.Sum(nil))[:8]
+	}
+	p.seen[value] = s
+	return s
+}
+
+// Replace replaces the remembered values within the path of the URL. The value
+// is replaced only if it's a whole element of the path, e.g. for the octocat
+// login https://github.com/octocat/Hello-World.git is replaced, but
+// https://github.com/octocats is not.
+func (p *Pseudonyms) Replace(url string) string {
+	values := make([]string, 0, len(p.seen))
+	for v := range p.seen {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+	start := pathStart(url)
+	head, tail := url[:start], url[start:]
+	for _, v := range values {
+		tail = replaceElem(tail, v, p.seen[v])
+	}
+	return head + tail
+}
+
+// pathStart gives the index of the URL's path, so the scheme and the host are
+// never replaced. It handles also the scp-like URLs, e.g. git@github.com:octocat/Hello-World.git.
+func pathStart(url string) int {
+	if i := strings.Index(url, "://"); i != -1 {
+		if j := strings.IndexByte(url[i+3:], '/'); j != -1 {
+			return i + 3 + j
+		}
+		return len(url)
+	}
+	if i := strings.IndexByte(url, ':'); i != -1 && strings.Contains(url[:i], "@") {
+		return i
+	}
+	return 0
+}
+
+func isSep(c byte) bool {
+	return strings.IndexByte("/:@?&=#.{}", c) != -1
+}
+
+func replaceElem(s, old, new string) string {
+	var buf bytes.Buffer
+	last := 0
+	for i := 0; i+len(old) <= len(s); {
+		j := strings.Index(s[i:], old)
+		if j == -1 {
+			break
+		}
+		i += j
+		end := i + len(old)
+		if (i == 0 || isSep(s[i-1])) && (end == len(s) || isSep(s[end])) {
+			buf.WriteString(s[last:i])
+			buf.WriteString(new)
+			last = end
+			i = end
+			continue
+		}
+		i++
+	}
+	if last == 0 {
+		return s
+	}
+	buf.WriteString(s[last:])
+	return buf.String()
+}
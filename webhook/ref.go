@@ -0,0 +1,202 @@
+package webhook
+
+import "strings"
+
+const (
+	headsPrefix = "refs/heads/"
+	tagsPrefix  = "refs/tags/"
+)
+
+// shortLen is a length of an abbreviated commit SHA, as displayed by GitHub.
+const shortLen = 7
+
+// RefEvent is implemented by the events, which are triggered for a git
+// reference - *PushEvent, *CreateEvent and *DeleteEvent.
+type RefEvent interface {
+	Event
+
+	// Branch gives the name of the branch, e.g. "master" for the
+	// "refs/heads/master" ref. It is empty if the ref is not a branch.
+	Branch() string
+
+	// Tag gives the name of the tag, e.g. "v1.0.0" for the "refs/tags/v1.0.0"
+	// ref. It is empty if the ref is not a tag.
+	Tag() string
+
+	// IsTag reports whether the ref is a tag.
+	IsTag() bool
+
+	// IsBranchCreation reports whether the event was triggered by creating
+	// a branch.
+	IsBranchCreation() bool
+
+	// IsBranchDeletion reports whether the event was triggered by deleting
+	// a branch.
+	IsBranchDeletion() bool
+
+	// IsDefaultBranch reports whether the ref is the default branch
+	// of the repository.
+	IsDefaultBranch() bool
+}
+
+var (
+	_ RefEvent = (*PushEvent)(nil)
+	_ RefEvent = (*CreateEvent)(nil)
+	_ RefEvent = (*DeleteEvent)(nil)
+)
+
+// ShortSHA abbreviates the given commit SHA to the 7 characters GitHub uses
+// when displaying commits.
+func ShortSHA(sha string) string {
+	if len(sha) > shortLen {
+		return sha[:shortLen]
+	}
+	return sha
+}
+
+func isDefault(branch string, repo *Repository) bool {
+	if branch == "" {
+		return false
+	}
+	if repo.DefaultBranch != "" {
+		return branch == repo.DefaultBranch
+	}
+	return branch == repo.MasterBranch
+}
+
+// refName gives the short name of the ref, if the type of the ref, as given
+// by the ref_type member of the create and delete events, is typ.
+func refName(ref, refType, typ string) string {
+	if refType != typ {
+		return ""
+	}
+	return ref
+}
+
+// Branch implements the RefEvent interface.
+func (e *PushEvent) Branch() string {
+	if strings.HasPrefix(e.Ref, headsPrefix) {
+		return e.Ref[len(headsPrefix):]
+	}
+	return ""
+}
+
+// Tag implements the RefEvent interface.
+func (e *PushEvent) Tag() string {
+	if strings.HasPrefix(e.Ref, tagsPrefix) {
+		return e.Ref[len(tagsPrefix):]
+	}
+	return ""
+}
+
+// IsTag implements the RefEvent interface.
+func (e *PushEvent) IsTag() bool {
+	return strings.HasPrefix(e.Ref, tagsPrefix)
+}
+
+// IsBranchCreation implements the RefEvent interface.
+func (e *PushEvent) IsBranchCreation() bool {
+	return e.Created && e.Branch() != ""
+}
+
+// IsBranchDeletion implements the RefEvent interface.
+func (e *PushEvent) IsBranchDeletion() bool {
+	return e.Deleted && e.Branch() != ""
+}
+
+// IsDefaultBranch implements the RefEvent interface.
+func (e *PushEvent) IsDefaultBranch() bool {
+	return isDefault(e.Branch(), &e.Repository)
+}
+
+// ShortBefore gives the abbreviated SHA of the commit the ref pointed to
+// before the push.
+func (e *PushEvent) ShortBefore() string {
+	return ShortSHA(e.Before)
+}
+
+// ShortAfter gives the abbreviated SHA of the commit the ref points to
+// after the push.
+func (e *PushEvent) ShortAfter() string {
+	return ShortSHA(e.After)
+}
+
+// CompareURL gives the URL of the page, which compares the commit range
+// of the push. If the payload does not carry the compare member, the URL
+// is built from the repository's URL and the Before and After commits.
+func (e *PushEvent) CompareURL() string {
+	if e.Compare != "" {
+		return e.Compare
+	}
+	if e.Repository.HTMLURL == "" || e.Before == "" || e.After == "" {
+		return ""
+	}
+	return e.Repository.HTMLURL + "/compare/" + e.Before + "..." + e.After
+}
+
+// Branch implements the RefEvent interface.
+func (e *CreateEvent) Branch() string {
+	return refName(e.Ref, e.RefType, "branch")
+}
+
+// Tag implements the RefEvent interface.
+func (e *CreateEvent) Tag() string {
+	return refName(e.Ref, e.RefType, "tag")
+}
+
+// IsTag implements the RefEvent interface.
+func (e *CreateEvent) IsTag() bool {
+	return e.RefType == "tag"
+}
+
+// IsBranchCreation implements the RefEvent interface.
+func (e *CreateEvent) IsBranchCreation() bool {
+	return e.RefType == "branch"
+}
+
+// IsBranchDeletion implements the RefEvent interface. It is always false
+// for the create event.
+func (e *CreateEvent) IsBranchDeletion() bool {
+	return false
+}
+
+// IsDefaultBranch implements the RefEvent interface. The create event carries
+// the default branch in its master_branch member, the repository's one is used
+// if it's missing.
+func (e *CreateEvent) IsDefaultBranch() bool {
+	if branch := e.Branch(); e.MasterBranch != "" {
+		return branch != "" && branch == e.MasterBranch
+	}
+	return isDefault(e.Branch(), &e.Repository)
+}
+
+// Branch implements the RefEvent interface.
+func (e *DeleteEvent) Branch() string {
+	return refName(e.Ref, e.RefType, "branch")
+}
+
+// Tag implements the RefEvent interface.
+func (e *DeleteEvent) Tag() string {
+	return refName(e.Ref, e.RefType, "tag")
+}
+
+// IsTag implements the RefEvent interface.
+func (e *DeleteEvent) IsTag() bool {
+	return e.RefType == "tag"
+}
+
+// IsBranchCreation implements the RefEvent interface. It is always false
+// for the delete event.
+func (e *DeleteEvent) IsBranchCreation() bool {
+	return false
+}
+
+// IsBranchDeletion implements the RefEvent interface.
+func (e *DeleteEvent) IsBranchDeletion() bool {
+	return e.RefType == "branch"
+}
+
+// IsDefaultBranch implements the RefEvent interface.
+func (e *DeleteEvent) IsDefaultBranch() bool {
+	return isDefault(e.Branch(), &e.Repository)
+}
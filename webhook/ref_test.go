@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestRefEvent(t *testing.T) {
+	cases := [...]struct {
+		file     string
+		event    RefEvent
+		branch   string
+		tag      string
+		creation bool
+		deletion bool
+		def      bool
+	}{
+		{"push.json", &PushEvent{}, "changes", "", false, false, false},
+		{"create.json", &CreateEvent{}, "", "0.0.1", false, false, false},
+		{"delete.json", &DeleteEvent{}, "", "simple-tag", false, false, false},
+	}
+	for i, cas := range cases {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", cas.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(body, cas.event); err != nil {
+			t.Fatalf("Unmarshal()=%v (i=%d)", err, i)
+		}
+		e := cas.event
+		if branch := e.Branch(); branch != cas.branch {
+			t.Errorf("want Branch()=%q; got %q (i=%d)", cas.branch, branch, i)
+		}
+		if tag := e.Tag(); tag != cas.tag {
+			t.Errorf("want Tag()=%q; got %q (i=%d)", cas.tag, tag, i)
+		}
+		if isTag := e.IsTag(); isTag != (cas.tag != "") {
+			t.Errorf("want IsTag()=%t; got %t (i=%d)", cas.tag != "", isTag, i)
+		}
+		if creation := e.IsBranchCreation(); creation != cas.creation {
+			t.Errorf("want IsBranchCreation()=%t; got %t (i=%d)", cas.creation, creation, i)
+		}
+		if deletion := e.IsBranchDeletion(); deletion != cas.deletion {
+			t.Errorf("want IsBranchDeletion()=%t; got %t (i=%d)", cas.deletion, deletion, i)
+		}
+		if def := e.IsDefaultBranch(); def != cas.def {
+			t.Errorf("want IsDefaultBranch()=%t; got %t (i=%d)", cas.def, def, i)
+		}
+	}
+}
+
+func TestRefEventSynthetic(t *testing.T) {
+	repo := Repository{DefaultBranch: "main", HTMLURL: "https://github.com/o/r"}
+	cases := [...]struct {
+		event    RefEvent
+		branch   string
+		tag      string
+		creation bool
+		deletion bool
+		def      bool
+	}{
+		{&PushEvent{Ref: "refs/heads/main", Repository: repo}, "main", "", false, false, true},
+		{&PushEvent{Ref: "refs/heads/feature", Created: true, Repository: repo}, "feature", "", true, false, false},
+		{&PushEvent{Ref: "refs/heads/feature", Deleted: true, Repository: repo}, "feature", "", false, true, false},
+		{&PushEvent{Ref: "refs/tags/v1.0.0", Created: true, Repository: repo}, "", "v1.0.0", false, false, false},
+		{&PushEvent{Ref: "refs/heads/master", Repository: Repository{MasterBranch: "master"}}, "master", "", false, false, true},
+		{&CreateEvent{Ref: "main", RefType: "branch", MasterBranch: "main"}, "main", "", true, false, true},
+		{&CreateEvent{Ref: "feature", RefType: "branch", Repository: repo}, "feature", "", true, false, false},
+		{&DeleteEvent{Ref: "feature", RefType: "branch", Repository: repo}, "feature", "", false, true, false},
+		{&DeleteEvent{Ref: "v1.0.0", RefType: "tag", Repository: repo}, "", "v1.0.0", false, false, false},
+	}
+	for i, cas := range cases {
+		e := cas.event
+		if branch := e.Branch(); branch != cas.branch {
+			t.Errorf("want Branch()=%q; got %q (i=%d)", cas.branch, branch, i)
+		}
+		if tag := e.Tag(); tag != cas.tag {
+			t.Errorf("want Tag()=%q; got %q (i=%d)", cas.tag, tag, i)
+		}
+		if creation := e.IsBranchCreation(); creation != cas.creation {
+			t.Errorf("want IsBranchCreation()=%t; got %t (i=%d)", cas.creation, creation, i)
+		}
+		if deletion := e.IsBranchDeletion(); deletion != cas.deletion {
+			t.Errorf("want IsBranchDeletion()=%t; got %t (i=%d)", cas.deletion, deletion, i)
+		}
+		if def := e.IsDefaultBranch(); def != cas.def {
+			t.Errorf("want IsDefaultBranch()=%t; got %t (i=%d)", cas.def, def, i)
+		}
+	}
+}
+
+func TestPushEventCommitRange(t *testing.T) {
+	var e PushEvent
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "push.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	if before := e.ShortBefore(); before != "9049f12" {
+		t.Errorf("want ShortBefore()=9049f12; got %s", before)
+	}
+	if after := e.ShortAfter(); after != "0d1a26e" {
+		t.Errorf("want ShortAfter()=0d1a26e; got %s", after)
+	}
+	if url := e.CompareURL(); url != e.Compare {
+		t.Errorf("want CompareURL()=%s; got %s", e.Compare, url)
+	}
+	e.Compare = ""
+	want := "https://github.com/baxterthehacker/public-repo/compare/" + e.Before + "..." + e.After
+	if url := e.CompareURL(); url != want {
+		t.Errorf("want CompareURL()=%s; got %s", want, url)
+	}
+	if sha := ShortSHA("abc"); sha != "abc" {
+		t.Errorf("want ShortSHA()=abc; got %s", sha)
+	}
+}
@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// DefaultCleanInterval is the default interval of the Dumper's janitor.
+var DefaultCleanInterval = time.Minute
+
+var (
+	dumpName = regexp.MustCompile(`^([a-z_]+-.+\.json|\d{4}-\d{2}-\d{2} at \d{2}\.\d{2}\.\d{2}\.\d{3})(\.gz)?$`)
+	dayName  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+type dumpFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Clean enforces the retention of the dumped files. It removes the files
+// older than MaxAge and then the oldest of the remaining files, until
+// there are at most MaxFiles files, which take at most MaxBytes in total.
+// The subdirectories, which were emptied by the removal, are removed as well.
+//
+// Only the files named the way the Dumper names them are subject to
+// the retention, e.g. push-<delivery>.json.gz, other files in Dir and its
+// subdirectories other than the daily ones are left intact.
+func (d *Dumper) Clean() error {
+	var files []dumpFile
+	var total int64
+	err := filepath.Walk(d.Dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() && path != d.Dir && !dayName.MatchString(fi.Name()) {
+			return filepath.SkipDir
+		}
+		if fi.Mode().IsRegular() && dumpName.MatchString(fi.Name()) {
+			files = append(files, dumpFile{path: path, size: fi.Size(), modTime: fi.ModTime()})
+			total += fi.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	var (
+		deadline = time.Now().Add(-d.MaxAge)
+		emptied  = make(map[string]struct{})
+		n        int
+		size     int64
+	)
+	for ; len(files) != 0; files = files[1:] {
+		f := files[0]
+		expired := d.MaxAge > 0 && f.modTime.Before(deadline)
+		if !expired && (d.MaxFiles <= 0 || len(files) <= d.MaxFiles) && (d.MaxBytes <= 0 || total <= d.MaxBytes) {
+			break
+		}
+		total -= f.size
+		if e := os.Remove(f.path); e != nil && !os.IsNotExist(e) {
+			err = nonil(err, e)
+			continue
+		}
+		n++
+		size += f.size
+		if dir := filepath.Dir(f.path); dir != filepath.Clean(d.Dir) {
+			emptied[dir] = struct{}{}
+		}
+	}
+	for dir := range emptied {
+		os.Remove(dir) // fails if the directory is not empty
+	}
+	if n != 0 {
+		d.logf("INFO %q: removed %d files (%d bytes)", d.Dir, n, size)
+	}
+	return err
+}
+
+// Close stops the background janitor. If the Store implements io.Closer,
+// Close closes it as well.
+func (d *Dumper) Close() error {
+	d.mu.Lock()
+	if d.stop == nil {
+		d.stop = make(chan struct{})
+	}
+	select {
+	case <-d.stop:
+	default:
+		close(d.stop)
+	}
+	d.mu.Unlock()
+	if c, ok := d.Store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (d *Dumper) retention() bool {
+	return d.MaxFiles > 0 || d.MaxBytes > 0 || d.MaxAge > 0
+}
+
+func (d *Dumper) startJanitor() {
+	if !d.retention() || d.Store != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stop != nil {
+		return
+	}
+	d.stop = make(chan struct{})
+	go d.janitor(d.stop)
+}
+
+func (d *Dumper) janitor(stop <-chan struct{}) {
+	interval := d.CleanInterval
+	if interval <= 0 {
+		interval = DefaultCleanInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := d.Clean(); err != nil {
+				d.logf("ERROR %q: error cleaning directory: %v", d.Dir, err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeFiles creates the files in the dir, the i-th file is i hours old.
+func writeFiles(t *testing.T, dir string, size int, names ...string) {
+	for i, name := range names {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, bytes.Repeat([]byte{'x'}, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mod := time.Now().Add(-time.Duration(i) * time.Hour)
+		if err := os.Chtimes(name, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	var files []string
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestClean(t *testing.T) {
+	files := []string{"a-1.json", "b-1.json", "2015-03-19/c-1.json", "2015-03-18/d-1.json.gz", "e-1.json"}
+	// The files, which are not named like the dumped ones, are kept.
+	other := []string{"push.json", "README.md", "fixtures/push-1.json"}
+	cases := [...]struct {
+		d    *Dumper
+		want []string
+	}{
+		{&Dumper{}, []string{"2015-03-18", "2015-03-18/d-1.json.gz", "2015-03-19", "2015-03-19/c-1.json", "a-1.json", "b-1.json", "e-1.json"}},
+		{&Dumper{MaxFiles: 3}, []string{"2015-03-19", "2015-03-19/c-1.json", "a-1.json", "b-1.json"}},
+		{&Dumper{MaxBytes: 250}, []string{"a-1.json", "b-1.json"}},
+		{&Dumper{MaxAge: 90 * time.Minute}, []string{"a-1.json", "b-1.json"}},
+		{&Dumper{MaxAge: 150 * time.Minute, MaxFiles: 4}, []string{"2015-03-19", "2015-03-19/c-1.json", "a-1.json", "b-1.json"}},
+		{&Dumper{MaxFiles: 4, MaxBytes: 300}, []string{"2015-03-19", "2015-03-19/c-1.json", "a-1.json", "b-1.json"}},
+	}
+	for i, cas := range cases {
+		dir := tempDir(t)
+		writeFiles(t, dir, 100, files...)
+		writeFiles(t, dir, 100, other...)
+		for _, name := range other {
+			old := time.Now().Add(-24 * time.Hour)
+			if err := os.Chtimes(filepath.Join(dir, filepath.FromSlash(name)), old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cas.d.Dir = dir
+		if err := cas.d.Clean(); err != nil {
+			t.Errorf("Clean()=%v (i=%d)", err, i)
+		}
+		var got []string
+		for _, name := range listFiles(t, dir) {
+			if !strings.HasPrefix(name, "fixtures") && name != "push.json" && name != "README.md" {
+				got = append(got, name)
+			}
+		}
+		for _, name := range other {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+				t.Errorf("want %s to be kept; got %v (i=%d)", name, err, i)
+			}
+		}
+		if strings.Join(got, " ") != strings.Join(cas.want, " ") {
+			t.Errorf("want files=%v; got %v (i=%d)", cas.want, got, i)
+		}
+		os.RemoveAll(dir)
+	}
+	if err := (&Dumper{Dir: "testdata/nonexisting", MaxFiles: 1}).Clean(); err != nil {
+		t.Errorf("want Clean()=nil for non-existing directory; got %v", err)
+	}
+}
+
+func TestDumpCompressDaily(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	d := Dump(dir, New(secret, BlanketHandler{}))
+	d.Compress = true
+	d.Daily = true
+	ts := httptest.NewServer(d)
+	defer ts.Close()
+	body := []byte(`{"zen":"Keep it logically awesome."}`)
+	req, err := http.NewRequest("POST", ts.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-GitHub-Event", "ping")
+	req.Header.Set("X-GitHub-Delivery", "1234")
+	req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do(req)=%v", err)
+	}
+	resp.Body.Close()
+	name := filepath.Join(dir, time.Now().UTC().Format("2006-01-02"), "ping-1234.json.gz")
+	var p []byte
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if p, err = ioutil.ReadFile(name); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("ReadFile()=%v", err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(p))
+	if err != nil {
+		t.Fatalf("gzip.NewReader()=%v", err)
+	}
+	if p, err = ioutil.ReadAll(r); err != nil {
+		t.Fatalf("ReadAll()=%v", err)
+	}
+	if !bytes.Equal(p, body) {
+		t.Errorf("want %s; got %s", body, p)
+	}
+}
+
+func TestDumpJanitor(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, 10, "push-a.json", "push-b.json", "push-c.json")
+	d := &Dumper{
+		Handler:       http.NotFoundHandler(),
+		Dir:           dir,
+		Events:        []string{"push"},
+		MaxFiles:      1,
+		CleanInterval: 10 * time.Millisecond,
+	}
+	defer d.Close()
+	d.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", nil))
+	var got []string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if got = listFiles(t, dir); len(got) == 1 {
+			break
+		}
+	}
+	if len(got) != 1 || got[0] != "push-a.json" {
+		t.Errorf("want janitor to keep push-a.json only; got %v", got)
+	}
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"math/rand"
+	"net/http"
+	"reflect"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Default backoffs used by the RetryPolicy.
+const (
+	DefaultMinBackoff = time.Second
+	DefaultMaxBackoff = 5 * time.Minute
+)
+
+// AttemptKey is a context key. It can be used in webhook handlers to access
+// the number of the current attempt of handling the event, starting from 1.
+var AttemptKey = &contextKey{"attempt"}
+
+// Attempt gives the number of the current attempt of handling the event,
+// which is stored in the context under the AttemptKey. It gives 1, if
+// the context has no such value.
+func Attempt(ctx context.Context) int {
+	if n, ok := ctx.Value(AttemptKey).(int); ok {
+		return n
+	}
+	return 1
+}
+
+// RetryPolicy configures retrying of the service's methods, which return
+// a non-nil error. The method is called again after a backoff, which grows
+// exponentially with each attempt:
+//
+//   MinBackoff * 2^(attempt-1)
+//
+// and it's capped at MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the method is called.
+	// If 0 or 1, the method is not retried.
+	MaxAttempts int
+
+	// MinBackoff is the backoff after the first attempt.
+	// If 0, DefaultMinBackoff is used instead.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum backoff.
+	// If 0, DefaultMaxBackoff is used instead.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the backoff, which is randomized, e.g.
+	// 0.5 makes the backoff vary between 50% and 100% of its value.
+	// If 0, the backoff is not randomized.
+	Jitter float64
+}
+
+// Backoff gives the time to wait after the given attempt.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = DefaultMinBackoff
+	}
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+	d := min
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	if p.Jitter > 0 {
+		j := p.Jitter
+		if j > 1 {
+			j = 1
+		}
+		d -= time.Duration(j * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// policy gives the retry policy for the event.
+func (h *Handler) policy(event string) *RetryPolicy {
+	if p, ok := h.Retries[event]; ok {
+		return p
+	}
+	return h.Retry
+}
+
+// retry calls fn until it succeeds or the retry policy for the event is
+// exhausted. It gives the status and error of the last attempt.
+func (h *Handler) retry(event string, w http.ResponseWriter, req *http.Request, fn func(int, http.ResponseWriter) (int, error)) (int, error) {
+	p := h.policy(event)
+	for attempt := 1; ; attempt++ {
+		status, err := fn(attempt, w)
+		if err == nil || attempt >= p.attempts() {
+			return status, err
+		}
+		d := p.Backoff(attempt)
+		h.logf("ERROR %s: X-GitHub-Event=%q Attempt=%d: %v (retrying in %v)", req.RemoteAddr, event, attempt, err, d)
+		time.Sleep(d)
+		w = &discardWriter{} // the response was written by the first attempt
+	}
+}
+
+// callError gives the error returned by a service's method, if any.
+func callError(out []reflect.Value) error {
+	if len(out) == 0 {
+		return nil
+	}
+	if err, ok := out[len(out)-1].Interface().(error); ok {
+		return err
+	}
+	return nil
+}
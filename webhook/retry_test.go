@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestBackoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, d := range want {
+		if got := p.Backoff(i + 1); got != d {
+			t.Errorf("want Backoff(%d)=%v; got %v", i+1, d, got)
+		}
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.Backoff(3); d < 2*time.Second || d > 4*time.Second {
+			t.Fatalf("want Backoff(3) within [2s, 4s]; got %v", d)
+		}
+	}
+	if d := (&RetryPolicy{}).Backoff(100); d != DefaultMaxBackoff {
+		t.Errorf("want Backoff(100)=%v; got %v", DefaultMaxBackoff, d)
+	}
+}
+
+// FlakyHandler fails handling each event until it was attempted n times.
+type FlakyHandler struct {
+	mu       sync.Mutex
+	n        int
+	attempts []int
+	events   []string
+}
+
+func (fh *FlakyHandler) Push(ctx context.Context, e *PushEvent) error {
+	fh.mu.Lock()
+	defer fh.mu.Unlock()
+	fh.attempts = append(fh.attempts, Attempt(ctx))
+	if len(fh.attempts) < fh.n {
+		return errors.New("service unavailable")
+	}
+	return nil
+}
+
+func (fh *FlakyHandler) All(event string, _ interface{}) error {
+	fh.mu.Lock()
+	defer fh.mu.Unlock()
+	fh.events = append(fh.events, event)
+	if len(fh.events) < fh.n {
+		return errors.New("service unavailable")
+	}
+	return nil
+}
+
+func TestHandlerRetry(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	dl, err := OpenDeadLetters(dir)
+	if err != nil {
+		t.Fatalf("OpenDeadLetters()=%v", err)
+	}
+	fh := &FlakyHandler{n: 3}
+	h := New(secret, fh)
+	h.Retry = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	h.Retries = map[string]*RetryPolicy{"ping": {MaxAttempts: 2, MinBackoff: time.Millisecond}}
+	h.DeadLetters = dl
+	if err := h.Dispatch(testEnvelope(t, "push", "1", `{"ref":"refs/heads/master"}`)); err != nil {
+		t.Fatalf("Dispatch()=%v", err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(fh.attempts, want) {
+		t.Errorf("want attempts=%v; got %v", want, fh.attempts)
+	}
+	// The ping event is attempted twice, thus it ends up in dead letters.
+	h.handleEnvelope(testEnvelope(t, "ping", "2", `{"zen":"Design for failure."}`))
+	if want := []string{"ping", "ping"}; !reflect.DeepEqual(fh.events, want) {
+		t.Errorf("want events=%v; got %v", want, fh.events)
+	}
+	entries, err := dl.List()
+	if err != nil {
+		t.Fatalf("List()=%v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("want 1 dead letter; got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Event != "ping" || e.Delivery != "2" || e.Attempts != 2 || e.Error != "service unavailable" {
+		t.Errorf("unexpected dead letter: %+v", e)
+	}
+	env, err := dl.Get(e.ID)
+	if err != nil {
+		t.Fatalf("Get()=%v", err)
+	}
+	// Rerunning the dead letter succeeds, since the service is available.
+	if err := h.Dispatch(env); err != nil {
+		t.Fatalf("Dispatch()=%v", err)
+	}
+	if err := dl.Remove(e.ID); err != nil {
+		t.Fatalf("Remove()=%v", err)
+	}
+	if files := listFiles(t, dir); len(files) != 0 {
+		t.Errorf("want no dead letters; got %v", files)
+	}
+	if _, err := dl.Get("../" + e.ID); err == nil {
+		t.Error("want Get to fail for invalid ID")
+	}
+}
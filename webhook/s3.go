@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Store uploads the dumped requests to a bucket of an S3-compatible object
+// storage, e.g. Amazon S3 or MinIO. The requests are signed with the AWS
+// Signature Version 4 and the objects are addressed path-style, that is
+// <Endpoint>/<Bucket>/<Prefix><name>.
+type S3Store struct {
+	Endpoint  string // URL of the storage, e.g. https://s3.us-east-1.amazonaws.com
+	Bucket    string // name of the bucket
+	Prefix    string // optional prefix of the object keys, e.g. "webhook/"
+	Region    string // region of the bucket, "us-east-1" if empty
+	AccessKey string // access key ID
+	SecretKey string // secret access key
+
+	// Client specifies an optional HTTP client for sending requests.
+	// If nil, http.DefaultClient is used instead.
+	Client *http.Client
+}
+
+// Put implements the DumpStore interface.
+func (s *S3Store) Put(name string, p []byte) error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return err
+	}
+	key := path.Join(s.Bucket, s.Prefix+name)
+	base := strings.TrimSuffix(u.EscapedPath(), "/")
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	u.RawPath = base + "/" + awsEscape(key, false)
+	req, err := http.NewRequest("PUT", u.String(), bytes.NewReader(p))
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(name, ".gz") {
+		req.Header.Set("Content-Type", "application/gzip")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	signV4(req, p, s.region(), "s3", s.AccessKey, s.SecretKey, time.Now())
+	client := http.DefaultClient
+	if s.Client != nil {
+		client = s.Client
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("PUT %s: %s: %s", u.Path, resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+func (s *S3Store) region() string {
+	if s.Region != "" {
+		return s.Region
+	}
+	return "us-east-1"
+}
+
+// signV4 signs the request with the AWS Signature Version 4, all the request's
+// headers are signed.
+func signV4(req *http.Request, body []byte, region, service, accessKey, secretKey string, t time.Time) {
+	date := t.UTC().Format("20060102T150405Z")
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	req.Header.Set("X-Amz-Date", date)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	headers := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		headers["host"] = req.Host
+	}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.Join(strings.Fields(strings.Join(v, ",")), " ")
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonical bytes.Buffer
+	for _, k := range names {
+		fmt.Fprintf(&canonical, "%s:%s\n", k, headers[k])
+	}
+	signed := strings.Join(names, ";")
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		vs := query[k]
+		sort.Strings(vs)
+		for _, v := range vs {
+			params = append(params, awsEscape(k, true)+"="+awsEscape(v, true))
+		}
+	}
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+	request := strings.Join([]string{
+		req.Method,
+		uri,
+		strings.Join(params, "&"),
+		canonical.String(),
+		signed,
+		payloadHash,
+	}, "\n")
+	scope := date[:8] + "/" + region + "/" + service + "/aws4_request"
+	sum = sha256.Sum256([]byte(request))
+	toSign := "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+	key := []byte("AWS4" + secretKey)
+	for _, s := range []string{date[:8], region, service, "aws4_request", toSign} {
+		key = hmacSHA256(key, s)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signed, hex.EncodeToString(key)))
+}
+
+func hmacSHA256(key []byte, s string) []byte {
+	mac := hmac.New(sha256.New, key)
+	io.WriteString(mac, s)
+	return mac.Sum(nil)
+}
+
+// awsEscape percent-encodes all the characters of s apart from the unreserved
+// ones. If slash is false, the '/' is not encoded either.
+func awsEscape(s string, slash bool) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !slash:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,263 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpoolEntry describes a single delivery stored in the Spool or DeadLetters.
+type SpoolEntry struct {
+	ID         string    // name of the entry's file
+	Event      string    // value of X-GitHub-Event header
+	Delivery   string    // value of X-GitHub-Delivery header
+	ReceivedAt time.Time // time the request was received
+	Size       int64     // size of the entry's file
+	Handling   bool      // whether the entry is being handled by a worker
+	Attempts   int       // number of failed attempts of handling
+	Error      string    // error of the last attempt
+}
+
+var entrySeq uint64
+
+// newEntryID gives a unique name for the entry's file, the names sort in
+// the order they were created.
+func newEntryID() string {
+	n := atomic.AddUint64(&entrySeq, 1)
+	return fmt.Sprintf("%019d-%06d.json", time.Now().UnixNano(), n%1000000)
+}
+
+// Spool is a durable, on-disk queue of deliveries. Each delivery is written
+// as an Envelope to a separate file in Dir and it is fsync'ed before Put
+// returns. The entry is removed after it is handled, thus the deliveries,
+// which were not handled before the process exited, are handled again after
+// the Spool is reopened - the Spool gives at-least-once semantics.
+//
+// Entries are handed out by Next in the order they were put. Entries,
+// which can't be read, are renamed to <id>.invalid and skipped.
+type Spool struct {
+	Dir string // directory where entries are written
+
+	// ErrorLog specifies an optional logger for the entries, which can't
+	// be read. If nil, logging goes to os.Stderr via the log package's
+	// standard logger.
+	ErrorLog *log.Logger
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    []string        // IDs of the entries waiting to be handled
+	handling map[string]bool // IDs of the entries being handled
+	closed   bool
+}
+
+// OpenSpool opens the spool in the given directory, creating it if needed.
+// The entries, which were left in the directory, are queued for handling.
+func OpenSpool(dir string) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Spool{Dir: dir, handling: make(map[string]bool)}
+	s.cond = sync.NewCond(&s.mu)
+	for _, fi := range fis {
+		switch name := fi.Name(); {
+		case strings.HasPrefix(name, ".tmp-"):
+			// Entries, which were not fully written, were never acknowledged.
+			os.Remove(filepath.Join(dir, name))
+		case strings.HasSuffix(name, ".json") && fi.Mode().IsRegular():
+			s.queue = append(s.queue, name)
+		}
+	}
+	sort.Strings(s.queue)
+	return s, nil
+}
+
+// Put writes the envelope to the spool and queues it for handling. When Put
+// returns with no error, the entry was synced to the disk.
+func (s *Spool) Put(env *Envelope) (string, error) {
+	var buf bytes.Buffer
+	if err := WriteEnvelope(&buf, env); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return "", errClosed
+	}
+	s.mu.Unlock()
+	id := newEntryID()
+	if err := writeEntry(s.Dir, id, buf.Bytes()); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.queue = append(s.queue, id)
+	s.mu.Unlock()
+	s.cond.Signal()
+	return id, nil
+}
+
+// writeEntry writes the entry to a temporary file, syncs it and renames it,
+// so the entry is never read partially.
+func writeEntry(dir, id string, p []byte) error {
+	f, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(p)
+	if err = nonil(err, f.Sync(), f.Close()); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(dir, id)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	// Syncing the directory makes the rename durable, it's not supported
+	// on every platform though.
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// Next blocks until there's an entry to handle and gives it. The entry must
+// be marked as handled with Done. Next returns an error when the spool
+// is closed.
+func (s *Spool) Next() (string, *Envelope, error) {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return "", nil, errClosed
+		}
+		id := s.queue[0]
+		s.queue = s.queue[1:]
+		s.handling[id] = true
+		s.mu.Unlock()
+		env, err := s.read(id)
+		if err == nil {
+			return id, env, nil
+		}
+		name := filepath.Join(s.Dir, id)
+		if e := os.Rename(name, name+".invalid"); e != nil {
+			s.logf("ERROR spool entry %s: %v (%v)", id, err, e)
+		} else {
+			s.logf("ERROR spool entry %s: %v, renamed to %s.invalid", id, err, id)
+		}
+		s.mu.Lock()
+		delete(s.handling, id)
+		s.mu.Unlock()
+	}
+}
+
+func (s *Spool) read(id string) (*Envelope, error) {
+	p, err := ioutil.ReadFile(filepath.Join(s.Dir, id))
+	if err != nil {
+		return nil, err
+	}
+	return ReadEnvelope(p)
+}
+
+func (s *Spool) logf(format string, args ...interface{}) {
+	if s.ErrorLog != nil {
+		s.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// Done removes the handled entry from the spool.
+func (s *Spool) Done(id string) error {
+	s.mu.Lock()
+	delete(s.handling, id)
+	s.mu.Unlock()
+	return os.Remove(filepath.Join(s.Dir, id))
+}
+
+// Len gives the number of entries, which are either waiting or being handled.
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue) + len(s.handling)
+}
+
+// Entries gives the entries of the spool, in the order they were put.
+func (s *Spool) Entries() ([]SpoolEntry, error) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.queue)+len(s.handling))
+	ids = append(ids, s.queue...)
+	handling := make(map[string]bool, len(s.handling))
+	for id := range s.handling {
+		ids = append(ids, id)
+		handling[id] = true
+	}
+	s.mu.Unlock()
+	sort.Strings(ids)
+	entries := make([]SpoolEntry, 0, len(ids))
+	for _, id := range ids {
+		e, err := ReadSpoolEntry(filepath.Join(s.Dir, id))
+		if os.IsNotExist(err) {
+			continue // handled in the meantime
+		}
+		if err != nil {
+			return nil, err
+		}
+		e.Handling = handling[id]
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// ReadSpoolEntry reads the description of the spool's entry from the file.
+// It can be used for inspecting a spool, which is not opened.
+func ReadSpoolEntry(file string) (SpoolEntry, error) {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return SpoolEntry{}, err
+	}
+	p, err := ioutil.ReadFile(file)
+	if err != nil {
+		return SpoolEntry{}, err
+	}
+	env, err := ReadEnvelope(p)
+	if err != nil {
+		return SpoolEntry{}, err
+	}
+	return SpoolEntry{
+		ID:         filepath.Base(file),
+		Event:      env.Event,
+		Delivery:   env.Delivery,
+		ReceivedAt: env.ReceivedAt,
+		Size:       fi.Size(),
+		Attempts:   env.Attempts,
+		Error:      env.Error,
+	}, nil
+}
+
+// Close wakes up the Next callers and makes further Put calls fail. The entries,
+// which were not handled, are kept on the disk.
+func (s *Spool) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errClosed
+	}
+	s.closed = true
+	s.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpool(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s, err := OpenSpool(dir)
+	if err != nil {
+		t.Fatalf("OpenSpool()=%v", err)
+	}
+	var ids []string
+	for _, delivery := range []string{"1", "2", "3"} {
+		id, err := s.Put(testEnvelope(t, "push", delivery, `{"ref":"refs/heads/master"}`))
+		if err != nil {
+			t.Fatalf("Put()=%v", err)
+		}
+		ids = append(ids, id)
+	}
+	id, env, err := s.Next()
+	if err != nil {
+		t.Fatalf("Next()=%v", err)
+	}
+	if id != ids[0] || env.Delivery != "1" {
+		t.Errorf("want id=%s delivery=1; got %s %s", ids[0], id, env.Delivery)
+	}
+	entries, err := s.Entries()
+	if err != nil {
+		t.Fatalf("Entries()=%v", err)
+	}
+	if len(entries) != 3 || !entries[0].Handling || entries[1].Handling || entries[2].Delivery != "3" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close()=%v", err)
+	}
+	if _, err := s.Put(env); err == nil {
+		t.Error("want Put to fail after Close")
+	}
+	if _, _, err := s.Next(); err == nil {
+		t.Error("want Next to fail after Close")
+	}
+	// Entries, which were not marked as done, are queued again.
+	writeFiles(t, dir, 10, ".tmp-123", "0-invalid.json")
+	if s, err = OpenSpool(dir); err != nil {
+		t.Fatalf("OpenSpool()=%v", err)
+	}
+	defer s.Close()
+	var buf bytes.Buffer
+	s.ErrorLog = log.New(&buf, "", 0)
+	if n := s.Len(); n != 4 {
+		t.Errorf("want Len()=4; got %d", n)
+	}
+	var got []string
+	for i := 0; i < 3; i++ {
+		id, _, err := s.Next()
+		if err != nil {
+			t.Fatalf("Next()=%v", err)
+		}
+		if err := s.Done(id); err != nil {
+			t.Fatalf("Done()=%v", err)
+		}
+		got = append(got, id)
+	}
+	if !reflect.DeepEqual(got, ids) {
+		t.Errorf("want ids=%v; got %v", ids, got)
+	}
+	if files := listFiles(t, dir); !reflect.DeepEqual(files, []string{"0-invalid.json.invalid"}) {
+		t.Errorf("want only the invalid entry to be left; got %v", files)
+	}
+	if !strings.Contains(buf.String(), "renamed to 0-invalid.json.invalid") {
+		t.Errorf("want the invalid entry to be logged; got %q", buf.String())
+	}
+}
+
+type BlockingHandler struct {
+	pings   chan *PingEvent
+	release chan struct{}
+}
+
+func (bh BlockingHandler) Ping(e *PingEvent) {
+	<-bh.release
+	bh.pings <- e
+}
+
+func TestHandlerSpool(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s, err := OpenSpool(dir)
+	if err != nil {
+		t.Fatalf("OpenSpool()=%v", err)
+	}
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "ping.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bh := BlockingHandler{pings: make(chan *PingEvent, 2), release: make(chan struct{})}
+	h := New(secret, bh)
+	h.Spool = s
+	for _, delivery := range []string{"1", "2"} {
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "ping")
+		req.Header.Set("X-GitHub-Delivery", delivery)
+		req.Header.Set("X-Hub-Signature", "sha1="+hmacHexDigest(secret, body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("want Code=202; got %d", w.Code)
+		}
+	}
+	if n := s.Len(); n != 2 {
+		t.Fatalf("want both deliveries to be spooled; got %d", n)
+	}
+	// Simulate a restart before the deliveries are handled.
+	s.Close()
+	close(bh.release)
+	h.Wait()
+	if s, err = OpenSpool(dir); err != nil {
+		t.Fatalf("OpenSpool()=%v", err)
+	}
+	if n := s.Len(); n < 1 {
+		t.Fatalf("want unhandled deliveries to be left in the spool; got %d", n)
+	}
+	h = New(secret, bh)
+	h.Spool = s
+	h.Start()
+	for deadline := time.Now().Add(5 * time.Second); s.Len() != 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the spool to be consumed; %d left", s.Len())
+		}
+	}
+	s.Close()
+	h.Wait()
+	want, err := decodePayload("ping", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(bh.pings); n != 2 {
+		t.Fatalf("want each delivery to be handled once; got %d", n)
+	}
+	for i := 0; i < 2; i++ {
+		if e := <-bh.pings; !reflect.DeepEqual(e, want) {
+			t.Errorf("want %+v; got %+v", want, e)
+		}
+	}
+	if files := listFiles(t, dir); len(files) != 0 {
+		t.Errorf("want spool to be empty; got %v", files)
+	}
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var errClosed = errors.New("store is closed")
+
+// DumpStore is a storage for the requests dumped by the Dumper.
+//
+// The name of each dumped request is a slash-separated path, e.g.
+// 2015-03-19/push-<delivery>.json.gz. Put can be called concurrently.
+type DumpStore interface {
+	Put(name string, p []byte) error
+}
+
+// DirStore writes the dumped requests to files in a local directory.
+type DirStore struct {
+	Dir string // directory where files are written
+
+	// WriteFile specifies an optional file writer.
+	// If nil, ioutil.WriteFile is used instead.
+	WriteFile func(string, []byte, os.FileMode) error
+}
+
+// Put implements the DumpStore interface. It creates the parent directories
+// of the file if needed.
+func (s *DirStore) Put(name string, p []byte) error {
+	name = filepath.Join(s.Dir, filepath.FromSlash(name))
+	if s.WriteFile != nil {
+		return s.WriteFile(name, p, 0644)
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, p, 0644)
+}
+
+// ndjsonRecord is a single line of the NDJSONStore file.
+type ndjsonRecord struct {
+	Name    string          `json:"name"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// NDJSONStore appends the dumped requests to a single file, one JSON object
+// per line:
+//
+//   {"name":"push-<delivery>.json","payload":{"ref":"refs/heads/master",...}}
+//
+// The payload is stored compacted. If it is not a valid JSON value, e.g. when
+// Dumper compresses the files, it is stored as a base64-encoded string.
+type NDJSONStore struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewNDJSONStore opens the file for appending, creating it if needed.
+func NewNDJSONStore(file string) (*NDJSONStore, error) {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &NDJSONStore{f: f}, nil
+}
+
+// Put implements the DumpStore interface.
+func (s *NDJSONStore) Put(name string, p []byte) error {
+	rec := ndjsonRecord{Name: name}
+	var buf bytes.Buffer
+	if json.Compact(&buf, p) == nil {
+		rec.Payload = buf.Bytes()
+	} else {
+		rec.Payload, _ = json.Marshal(p)
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return errClosed
+	}
+	_, err = s.f.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (s *NDJSONStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return errClosed
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// DumpFile is a single request stored by the MemStore.
+type DumpFile struct {
+	Name string
+	Data []byte
+}
+
+// MemStore keeps the dumped requests in memory. When it is full, the oldest
+// requests are overwritten. It is meant to be used by tests.
+type MemStore struct {
+	mu    sync.Mutex
+	files []DumpFile
+	next  int // index of the oldest file, when the ring is full
+	size  int
+}
+
+// NewMemStore creates new MemStore, which keeps at most size latest requests.
+// If size is 0, the store is unbounded.
+func NewMemStore(size int) *MemStore {
+	return &MemStore{size: size}
+}
+
+// Put implements the DumpStore interface.
+func (s *MemStore) Put(name string, p []byte) error {
+	f := DumpFile{Name: name, Data: append([]byte(nil), p...)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size <= 0 || len(s.files) < s.size {
+		s.files = append(s.files, f)
+		return nil
+	}
+	s.files[s.next] = f
+	s.next = (s.next + 1) % s.size
+	return nil
+}
+
+// Files gives the stored requests, from the oldest to the latest one.
+func (s *MemStore) Files() []DumpFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make([]DumpFile, 0, len(s.files))
+	files = append(files, s.files[s.next:]...)
+	return append(files, s.files[:s.next]...)
+}
+
+// Get gives the latest stored request of the given name.
+func (s *MemStore) Get(name string) ([]byte, bool) {
+	files := s.Files()
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i].Name == name {
+			return files[i].Data, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDirStore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s := &DirStore{Dir: dir}
+	for _, name := range []string{"push-1.json", "2015-03-19/push-2.json"} {
+		if err := s.Put(name, []byte(name)); err != nil {
+			t.Fatalf("Put(%q)=%v", name, err)
+		}
+		p, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(p) != name {
+			t.Errorf("want %q; got %q", name, p)
+		}
+	}
+}
+
+func TestNDJSONStore(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "dump.ndjson")
+	s, err := NewNDJSONStore(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	puts := []DumpFile{
+		{"push-1.json", []byte("{\n\t\"ref\": \"refs/heads/master\"\n}\n")},
+		{"push-2.json.gz", []byte{0x1f, 0x8b, 0x08}},
+	}
+	for _, f := range puts {
+		if err := s.Put(f.Name, f.Data); err != nil {
+			t.Fatalf("Put(%q)=%v", f.Name, err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close()=%v", err)
+	}
+	if err := s.Put("push-3.json", []byte("{}")); err != errClosed {
+		t.Errorf("want Put()=errClosed after Close; got %v", err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []string
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		lines = append(lines, scanner.Text())
+	}
+	want := []string{
+		`{"name":"push-1.json","payload":{"ref":"refs/heads/master"}}`,
+		`{"name":"push-2.json.gz","payload":"H4sI"}`,
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("want lines=%q; got %q", want, lines)
+	}
+	var rec struct {
+		Payload []byte `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil || !reflect.DeepEqual(rec.Payload, puts[1].Data) {
+		t.Errorf("want base64-encoded payload; got %v (err=%v)", rec.Payload, err)
+	}
+}
+
+func TestMemStore(t *testing.T) {
+	s := NewMemStore(2)
+	for _, name := range []string{"a", "b", "c", "b"} {
+		if err := s.Put(name, []byte(name+"-data")); err != nil {
+			t.Fatalf("Put(%q)=%v", name, err)
+		}
+	}
+	files := s.Files()
+	if len(files) != 2 || files[0].Name != "c" || files[1].Name != "b" {
+		t.Errorf("want [c b] files; got %v", files)
+	}
+	if p, ok := s.Get("b"); !ok || string(p) != "b-data" {
+		t.Errorf("want b-data; got %q, %t", p, ok)
+	}
+	if _, ok := s.Get("a"); ok {
+		t.Error("want a to be overwritten")
+	}
+}
+
+func TestDumpTo(t *testing.T) {
+	store := NewMemStore(0)
+	d := DumpTo(store, New(secret, BlanketHandler{}))
+	d.Daily = true
+	testHandler(t, d)
+	var files []DumpFile
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if files = store.Files(); len(files) == len(payloads) {
+			break
+		}
+	}
+	if len(files) != len(payloads) {
+		t.Fatalf("want %d files; got %d", len(payloads), len(files))
+	}
+	day := time.Now().UTC().Format("2006-01-02") + "/"
+	for _, f := range files {
+		if filepath.ToSlash(filepath.Dir(f.Name))+"/" != day || !json.Valid(f.Data) {
+			t.Errorf("want JSON payload within %s; got %s", day, f.Name)
+		}
+	}
+	if err := d.Close(); err != nil {
+		t.Errorf("Close()=%v", err)
+	}
+}
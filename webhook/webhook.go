@@ -16,6 +16,8 @@
 //   -------------------+-----------------------------
 //    delete            | *webhook.DeleteEvent
 //   -------------------+-----------------------------
+//    deploy_key        | *webhook.DeployKeyEvent
+//   -------------------+-----------------------------
 //    deployment        | *webhook.DeploymentEvent
 //   -------------------+-----------------------------
 //    deployment_status | *webhook.DeploymentStatusEvent
@@ -36,10 +38,22 @@
 //   -------------------+-----------------------------
 //    issues            | *webhook.IssuesEvent
 //   -------------------+-----------------------------
+//    label             | *webhook.LabelEvent
+//   -------------------+-----------------------------
 //    member            | *webhook.MemberEvent
 //   -------------------+-----------------------------
 //    membership        | *webhook.MembershipEvent
 //   -------------------+-----------------------------
+//    merge_group       | *webhook.MergeGroupEvent
+//   -------------------+-----------------------------
+//    meta              | *webhook.MetaEvent
+//   -------------------+-----------------------------
+//    org_block         | *webhook.OrgBlockEvent
+//   -------------------+-----------------------------
+//    organization      | *webhook.OrganizationEvent
+//   -------------------+-----------------------------
+//    package           | *webhook.PackageEvent
+//   -------------------+-----------------------------
 //    page_build        | *webhook.PageBuildEvent
 //   -------------------+-----------------------------
 //    ping              | *webhook.PingEvent
@@ -50,18 +64,73 @@
 //   -------------------+-----------------------------
 //    push              | *webhook.PushEvent
 //   -------------------+-----------------------------
+//    registry_package  | *webhook.RegistryPackageEvent
+//   -------------------+-----------------------------
 //    release           | *webhook.ReleaseEvent
 //   -------------------+-----------------------------
 //    repository        | *webhook.RepositoryEvent
 //   -------------------+-----------------------------
+//    repository_import | *webhook.RepositoryImportEvent
+//   -------------------+-----------------------------
+//    sponsorship       | *webhook.SponsorshipEvent
+//   -------------------+-----------------------------
+//    star              | *webhook.StarEvent
+//   -------------------+-----------------------------
 //    status            | *webhook.StatusEvent
 //   -------------------+-----------------------------
+//    team              | *webhook.TeamEvent
+//   -------------------+-----------------------------
 //    team_add          | *webhook.TeamAddEvent
 //   -------------------+-----------------------------
 //    watch             | *webhook.WatchEvent
 //   -------------------+---------+----------------------------------------
+//    branch_protection_rule      | *webhook.BranchProtectionRuleEvent
+//   -----------------------------+----------------------------------------
+//    pull_request_review         | *webhook.PullRequestReviewEvent
+//   -----------------------------+----------------------------------------
 //    pull_request_review_comment | *webhook.PullRequestReviewCommentEvent
 //   -----------------------------+----------------------------------------
+//    repository_dispatch         | *webhook.RepositoryDispatchEvent
+//   -----------------------------+----------------------------------------
+//
+// Each of the above event structs embeds the Common struct, which holds the
+// installation, organization and enterprise objects that GitHub attaches to
+// the deliveries. They're nil if the delivery does not carry them.
+//
+// Payload objects of the same shape share a single type - every user object,
+// e.g. the sender, owner or assignee, is a User, every repository object is
+// a Repository. The former per-member names, like Sender or Owner, are kept
+// as aliases of the shared types.
+//
+// Events, which are triggered by editing an object, carry the Changes member
+// with the previous values of the edited members. The Fields method of
+// the changes lists which members were edited.
+//
+// The push, create and delete events implement the RefEvent interface, which
+// tells the branch or tag the event was triggered for.
+//
+// Derived events
+//
+// Some of the delivered events are additionally turned into higher-level ones,
+// which tell what has happened without inspecting the payload:
+//
+//            Name        |               Type              |          Derived from
+//   ---------------------+---------------------------------+--------------------------------
+//    branch_created      | *webhook.BranchCreatedEvent     | create of a branch
+//   ---------------------+---------------------------------+--------------------------------
+//    pull_request_merged | *webhook.PullRequestMergedEvent | pull_request closed and merged
+//   ---------------------+---------------------------------+--------------------------------
+//    release_published   | *webhook.ReleasePublishedEvent  | release published
+//   ---------------------+---------------------------------+--------------------------------
+//    review_approved     | *webhook.ReviewApprovedEvent    | pull_request_review approved
+//   ---------------------+---------------------------------+--------------------------------
+//    tag_pushed          | *webhook.TagPushedEvent         | push of a tag
+//   ---------------------+---------------------------------+--------------------------------
+//
+// Each of the derived event structs embeds the event it was derived from.
+// The derived events are dispatched after the delivered one, only to methods,
+// which explicitly take them as an argument - they're never passed to the
+// method handling all the events. See Derive for details.
 //
 // Handler service
 //
@@ -73,10 +142,23 @@
 //
 //   func (T) MethodName(eventName string, eventPayload interface{})
 //
+// or, if the payload is going to be accessed with the Event interface:
+//
+//   func (T) MethodName(eventName string, eventPayload webhook.Event)
+//
 // If a handler service has defined both: methods for handling particular events
 // and method hadling all events, the former has the priority - if there exists
 // no method for handling particular event type, the blanket handler will be used.
 //
+// Any of the methods may return an error, e.g.:
+//
+//   func (T) Push(ctx context.Context, event *webhook.PushEvent) error
+//
+// A non-nil error marks the event as failed. If the Handler has a retry policy,
+// the method is called again after a backoff, and the deliveries, which failed
+// after all the attempts, are written to the Handler's DeadLetters, if any.
+// See RetryPolicy and DeadLetters for details.
+//
 // Example
 //
 // The following handler service logs each incoming event.
@@ -121,7 +203,7 @@ import (
 
 //go:generate go run generate_payloads.go
 //go:generate go test -run TestGenerateMockHelper -- -generate
-//go:generate gofmt -w -s payloads.go mock_test.go
+//go:generate gofmt -w -s payloads.go mock_test.go webhooktest/handlers.go webhooktest/builders.go
 
 var null = []byte("null")
 
@@ -158,6 +240,50 @@ func (t *Time) UnmarshalJSON(p []byte) (err error) {
 	return nil
 }
 
+// Common holds the members, which GitHub attaches to deliveries of every event
+// type. It is embedded in each of the *Event structs; the members are nil
+// when they're missing from a payload.
+type Common struct {
+	Installation *Installation `json:"installation,omitempty"`
+	Organization *Organization `json:"organization,omitempty"`
+	Enterprise   *Enterprise   `json:"enterprise,omitempty"`
+}
+
+// GetInstallation gives the GitHub App installation the event was delivered for.
+func (c *Common) GetInstallation() *Installation {
+	return c.Installation
+}
+
+// GetOrganization gives the organization the event was triggered within.
+func (c *Common) GetOrganization() *Organization {
+	return c.Organization
+}
+
+// GetEnterprise gives the enterprise account the event was triggered within.
+func (c *Common) GetEnterprise() *Enterprise {
+	return c.Enterprise
+}
+
+// Event is implemented by each of the *Event payload types. It gives access
+// to the members the event types have in common, so they can be handled
+// without a type switch.
+//
+// The GetRepository and GetSender methods return nil, if the event type
+// does not have such member. The GetAction method returns an empty string,
+// if the event type has no action.
+type Event interface {
+	// EventName gives the name of the event type, as delivered with
+	// the X-GitHub-Event header.
+	EventName() string
+
+	GetRepository() *Repository
+	GetSender() *User
+	GetAction() string
+	GetInstallation() *Installation
+	GetOrganization() *Organization
+	GetEnterprise() *Enterprise
+}
+
 type payloadsMap map[string]reflect.Type
 
 func (p payloadsMap) Type(name string) (reflect.Type, bool) {
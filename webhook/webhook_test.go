@@ -0,0 +1,256 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCommon(t *testing.T) {
+	type common interface {
+		GetInstallation() *Installation
+		GetOrganization() *Organization
+		GetEnterprise() *Enterprise
+	}
+	for event, typ := range payloads {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", event+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(body, &raw); err != nil {
+			t.Fatalf("Unmarshal()=%v (event=%s)", err, event)
+		}
+		v := reflect.New(typ).Interface()
+		if err := json.Unmarshal(body, v); err != nil {
+			t.Fatalf("Unmarshal()=%v (event=%s)", err, event)
+		}
+		c, ok := v.(common)
+		if !ok {
+			t.Errorf("%T does not embed Common (event=%s)", v, event)
+			continue
+		}
+		if _, ok := raw["installation"]; ok != (c.GetInstallation() != nil) {
+			t.Errorf("want installation=%t; got %v (event=%s)", ok, c.GetInstallation(), event)
+		}
+		if _, ok := raw["organization"]; ok != (c.GetOrganization() != nil) {
+			t.Errorf("want organization=%t; got %v (event=%s)", ok, c.GetOrganization(), event)
+		}
+		if _, ok := raw["enterprise"]; ok != (c.GetEnterprise() != nil) {
+			t.Errorf("want enterprise=%t; got %v (event=%s)", ok, c.GetEnterprise(), event)
+		}
+	}
+}
+
+func TestEvent(t *testing.T) {
+	for event, typ := range payloads {
+		e, ok := reflect.New(typ).Interface().(Event)
+		if !ok {
+			t.Errorf("%v does not implement Event (event=%s)", typ, event)
+			continue
+		}
+		if name := e.EventName(); name != event {
+			t.Errorf("want EventName()=%s; got %s", event, name)
+		}
+		_, repo := typ.FieldByName("Repository")
+		if got := e.GetRepository() != nil; got != repo {
+			t.Errorf("want GetRepository()!=nil to be %t; got %t (event=%s)", repo, got, event)
+		}
+		_, sender := typ.FieldByName("Sender")
+		if got := e.GetSender() != nil; got != sender {
+			t.Errorf("want GetSender()!=nil to be %t; got %t (event=%s)", sender, got, event)
+		}
+	}
+}
+
+func TestNullable(t *testing.T) {
+	cases := [...]struct {
+		file      string
+		assignee  bool
+		milestone bool
+		mergeable bool
+	}{
+		{"pull_request.json", false, true, false},
+		{"pull_request-assignee_bug.json", true, false, true},
+		{"pull_request_review_comment.json", false, false, false},
+	}
+	for i, cas := range cases {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", cas.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var e struct {
+			PullRequest PullRequest `json:"pull_request"`
+		}
+		if err := json.Unmarshal(body, &e); err != nil {
+			t.Fatalf("Unmarshal()=%v (i=%d)", err, i)
+		}
+		if got := e.PullRequest.Assignee != nil; got != cas.assignee {
+			t.Errorf("want Assignee!=nil to be %t; got %t (i=%d)", cas.assignee, got, i)
+		}
+		if got := e.PullRequest.Milestone != nil; got != cas.milestone {
+			t.Errorf("want Milestone!=nil to be %t; got %t (i=%d)", cas.milestone, got, i)
+		}
+		// The mergeable member is null until GitHub computes it.
+		if got := e.PullRequest.Mergeable != nil; got != cas.mergeable {
+			t.Errorf("want Mergeable!=nil to be %t; got %t (i=%d)", cas.mergeable, got, i)
+		}
+		if e.PullRequest.MergedBy != nil {
+			t.Errorf("want MergedBy=nil; got %v (i=%d)", e.PullRequest.MergedBy, i)
+		}
+	}
+}
+
+func TestInt64IDs(t *testing.T) {
+	counters := map[string]bool{
+		"Additions":    true,
+		"ChangedFiles": true,
+		"Comments":     true,
+		"Deletions":    true,
+		"Forks":        true,
+		"OpenIssues":   true,
+		"Size":         true,
+	}
+	seen := make(map[reflect.Type]bool)
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			walk(typ.Elem())
+			return
+		case reflect.Struct:
+		default:
+			return
+		}
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if strings.HasSuffix(f.Name, "ID") || strings.HasSuffix(f.Name, "Count") || counters[f.Name] {
+				kind := f.Type.Kind()
+				if kind == reflect.Ptr {
+					kind = f.Type.Elem().Kind()
+				}
+				switch kind {
+				case reflect.Int, reflect.Int32, reflect.Float64:
+					t.Errorf("want %s.%s to be int64; got %v", typ.Name(), f.Name, f.Type)
+				}
+			}
+			walk(f.Type)
+		}
+	}
+	for _, typ := range payloads {
+		walk(typ)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	type changes interface {
+		Fields() []string
+	}
+	cases := [...]struct {
+		file   string
+		event  Event
+		fields []string
+	}{
+		{"pull_request-edited.json", &PullRequestEvent{}, []string{"base.ref", "base.sha", "body", "title"}},
+		{"pull_request.json", &PullRequestEvent{}, nil},
+		{"issues-edited.json", &IssuesEvent{}, []string{"body", "title"}},
+		{"issue_comment-edited.json", &IssueCommentEvent{}, []string{"body"}},
+		{"repository-renamed.json", &RepositoryEvent{}, []string{"repository.name"}},
+		{"label.json", &LabelEvent{}, []string{"color", "name"}},
+	}
+	for i, cas := range cases {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", cas.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(body, cas.event); err != nil {
+			t.Fatalf("Unmarshal()=%v (i=%d)", err, i)
+		}
+		c := reflect.ValueOf(cas.event).Elem().FieldByName("Changes").Interface().(changes)
+		if fields := c.Fields(); !reflect.DeepEqual(fields, cas.fields) {
+			t.Errorf("want Fields()=%v; got %v (i=%d)", cas.fields, fields, i)
+		}
+	}
+	var e PullRequestEvent
+	if err := json.Unmarshal([]byte(`{"changes":{"title":{"from":"old"}}}`), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Changes.Title == nil || e.Changes.Title.From != "old" {
+		t.Errorf("want Changes.Title.From=old; got %+v", e.Changes.Title)
+	}
+}
+
+func TestSharedTypes(t *testing.T) {
+	cases := [...]struct {
+		typ    interface{}
+		shared interface{}
+	}{
+		{Sender{}, User{}},
+		{Owner{}, User{}},
+		{Assignee{}, User{}},
+		{Pusher{}, User{}},
+		{Forkee{}, Repository{}},
+		{Repo{}, Repository{}},
+		{HeadCommit{}, PushCommit{}},
+	}
+	for i, cas := range cases {
+		if typ, shared := reflect.TypeOf(cas.typ), reflect.TypeOf(cas.shared); typ != shared {
+			t.Errorf("want %v to be %v (i=%d)", typ, shared, i)
+		}
+	}
+	var e PushEvent
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "push.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Sender.Login == "" || e.Repository.Owner.Name == "" {
+		t.Errorf("want non-empty Sender.Login and Repository.Owner.Name; got %+v and %+v", e.Sender, e.Repository.Owner)
+	}
+}
+
+func TestPullRequest(t *testing.T) {
+	var e PullRequestEvent
+	body, err := ioutil.ReadFile(filepath.Join("testdata", "pull_request-auto_merge_enabled.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	pr := e.PullRequest
+	switch {
+	case pr.Draft:
+		t.Error("want Draft=false")
+	case pr.AutoMerge == nil || pr.AutoMerge.MergeMethod != "squash":
+		t.Errorf("want AutoMerge.MergeMethod=squash; got %+v", pr.AutoMerge)
+	case len(pr.RequestedReviewers) != 1 || len(pr.RequestedTeams) != 1:
+		t.Errorf("want 1 requested reviewer and team; got %d and %d", len(pr.RequestedReviewers), len(pr.RequestedTeams))
+	case len(pr.Labels) != 1 || pr.Labels[0].Name != "bug":
+		t.Errorf("want Labels=[bug]; got %+v", pr.Labels)
+	case pr.AuthorAssociation != "MEMBER":
+		t.Errorf("want AuthorAssociation=MEMBER; got %q", pr.AuthorAssociation)
+	case pr.Head.Repo.Visibility != "public" || pr.Base.Repo.License == nil:
+		t.Errorf("want full Head.Repo and Base.Repo; got %+v and %+v", pr.Head.Repo, pr.Base.Repo)
+	}
+	e = PullRequestEvent{}
+	body, err = ioutil.ReadFile(filepath.Join("testdata", "pull_request-ready_for_review.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatal(err)
+	}
+	if !e.PullRequest.Draft || e.PullRequest.AutoMerge != nil {
+		t.Errorf("want Draft=true and AutoMerge=nil; got %t and %+v", e.PullRequest.Draft, e.PullRequest.AutoMerge)
+	}
+}
@@ -0,0 +1,528 @@
+package webhooktest
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+const (
+	apiURL  = "https://api.github.com"
+	htmlURL = "https://github.com"
+	zeroSHA = "0000000000000000000000000000000000000000"
+)
+
+// Option configures the payloads built by the New*Event functions.
+type Option func(*builder)
+
+// Repo sets the full name of the repository the event is triggered for,
+// e.g. "octocat/hello-world". The owner of the repository is the default
+// sender of the event.
+func Repo(fullName string) Option {
+	return func(b *builder) {
+		if i := strings.IndexByte(fullName, '/'); i != -1 {
+			b.owner, b.name = fullName[:i], fullName[i+1:]
+		} else {
+			b.name = fullName
+		}
+	}
+}
+
+// Ref sets the git reference the event is triggered for. The short branch
+// names are expanded, e.g. "main" to "refs/heads/main".
+func Ref(ref string) Option {
+	return func(b *builder) {
+		if !strings.HasPrefix(ref, "refs/") {
+			ref = "refs/heads/" + ref
+		}
+		b.ref = ref
+	}
+}
+
+// Tag sets the git tag the event is triggered for. It is a shorthand for
+// Ref("refs/tags/" + tag).
+func Tag(tag string) Option {
+	return Ref("refs/tags/" + tag)
+}
+
+// DefaultBranch sets the name of the repository's default branch.
+func DefaultBranch(branch string) Option {
+	return func(b *builder) { b.defaultBranch = branch }
+}
+
+// Action sets the action of the event, e.g. "opened" for the pull_request
+// event. Each event has a sane default action.
+func Action(action string) Option {
+	return func(b *builder) { b.action = action }
+}
+
+// Sender sets the login of the user, who triggered the event.
+func Sender(login string) Option {
+	return func(b *builder) { b.sender = login }
+}
+
+// Org makes the repository owned by the organization and sets the
+// organization member of the event.
+func Org(login string) Option {
+	return func(b *builder) { b.org = login }
+}
+
+// Installation sets the ID of the GitHub App installation the event is
+// delivered for.
+func Installation(id int64) Option {
+	return func(b *builder) { b.installation = id }
+}
+
+// SHA sets the commit SHA the event is triggered for - it is e.g. the head
+// commit of a push or pull request.
+func SHA(sha string) Option {
+	return func(b *builder) { b.sha = sha }
+}
+
+// Before sets the commit SHA the ref pointed to before the push.
+func Before(sha string) Option {
+	return func(b *builder) { b.before = sha }
+}
+
+// Number sets the number of the pull request or issue.
+func Number(n int) Option {
+	return func(b *builder) { b.number = n }
+}
+
+// Paths sets the files modified by the pushed commit.
+func Paths(paths ...string) Option {
+	return func(b *builder) { b.paths = paths }
+}
+
+// At sets the time the event was triggered at.
+func At(t time.Time) Option {
+	return func(b *builder) { b.time = t.UTC() }
+}
+
+type builder struct {
+	owner         string
+	name          string
+	ref           string
+	defaultBranch string
+	action        string
+	sender        string
+	org           string
+	installation  int64
+	sha           string
+	before        string
+	number        int
+	paths         []string
+	time          time.Time
+
+	id    int64
+	users map[string]int64
+}
+
+func sha(s string) string {
+	p := sha1.Sum([]byte(s))
+	return hex.EncodeToString(p[:])
+}
+
+// compareSHA abbreviates the SHA to the 12 characters GitHub uses in
+// compare URLs.
+func compareSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+func newBuilder(action string, opts []Option) *builder {
+	b := &builder{
+		owner:  "octocat",
+		name:   "hello-world",
+		ref:    "refs/heads/main",
+		action: action,
+		number: 1,
+		time:   time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC),
+		id:     1000,
+		users:  make(map[string]int64),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.defaultBranch == "" {
+		b.defaultBranch = "main"
+	}
+	if b.sender == "" {
+		b.sender = b.owner
+	}
+	if b.sha == "" {
+		b.sha = sha(b.owner + "/" + b.name + "@" + b.ref)
+	}
+	if b.before == "" {
+		b.before = sha(b.sha)
+	}
+	if len(b.paths) == 0 {
+		b.paths = []string{"README.md"}
+	}
+	return b
+}
+
+func (b *builder) nextID() int64 {
+	b.id++
+	return b.id
+}
+
+func (b *builder) fullName() string { return b.owner + "/" + b.name }
+func (b *builder) repoAPI() string  { return apiURL + "/repos/" + b.fullName() }
+func (b *builder) repoHTML() string { return htmlURL + "/" + b.fullName() }
+
+// branch gives the name of the branch of the ref or the default branch,
+// if the ref is not a branch.
+func (b *builder) branch() string {
+	if strings.HasPrefix(b.ref, "refs/heads/") {
+		return b.ref[len("refs/heads/"):]
+	}
+	return b.defaultBranch
+}
+
+func (b *builder) tag() string {
+	if strings.HasPrefix(b.ref, "refs/tags/") {
+		return b.ref[len("refs/tags/"):]
+	}
+	return ""
+}
+
+func nodeID(kind string, id int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s%d", kind, id)))
+}
+
+var (
+	userType = reflect.TypeOf(webhook.User{})
+	repoType = reflect.TypeOf(webhook.Repository{})
+	timeType = reflect.TypeOf(webhook.Time{})
+)
+
+// unset are the members, which are null unless the object is in particular
+// state, e.g. a pull request is closed.
+var unset = map[string]bool{
+	"ClosedAt": true,
+	"DueOn":    true,
+	"MergedAt": true,
+}
+
+func snakeCase(s string) string {
+	var buf []rune
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i != 0 {
+				buf = append(buf, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		buf = append(buf, r)
+	}
+	return string(buf)
+}
+
+// fill populates the members of the struct v with defaults. The URL members
+// are built from the api and html URLs of the object.
+func (b *builder) fill(v reflect.Value, api, html string) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f, fv := typ.Field(i), v.Field(i)
+		if f.Anonymous || f.PkgPath != "" {
+			continue
+		}
+		switch fv.Type() {
+		case userType:
+			fv.Set(reflect.ValueOf(b.user(b.sender)))
+			continue
+		case repoType:
+			fv.Set(reflect.ValueOf(b.repository()))
+			continue
+		case timeType:
+			if !unset[f.Name] {
+				fv.Set(reflect.ValueOf(webhook.Time{Time: b.time}))
+			}
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(b.str(f.Name, api, html))
+		case reflect.Int64:
+			if f.Name == "ID" || strings.HasSuffix(f.Name, "ID") {
+				fv.SetInt(b.nextID())
+			}
+		case reflect.Int:
+			if f.Name == "Number" {
+				fv.SetInt(int64(b.number))
+			}
+		case reflect.Struct:
+			elem := "/" + snakeCase(f.Name)
+			b.fill(fv, api+elem, html+elem)
+		}
+	}
+}
+
+func (b *builder) str(name, api, html string) string {
+	switch name {
+	case "URL":
+		return api
+	case "HTMLURL":
+		return html
+	case "NodeID":
+		return nodeID("N", b.id)
+	case "SHA", "CommitID", "HeadSHA", "After":
+		return b.sha
+	case "Before":
+		return b.before
+	case "TreeID":
+		return sha(b.sha + "^{tree}")
+	case "Ref":
+		return b.ref
+	case "Action":
+		return b.action
+	case "DefaultBranch", "MasterBranch":
+		return b.defaultBranch
+	}
+	if strings.HasSuffix(name, "URL") {
+		return api + "/" + snakeCase(strings.TrimSuffix(name, "URL"))
+	}
+	return ""
+}
+
+func (b *builder) userID(login string) int64 {
+	id, ok := b.users[login]
+	if !ok {
+		id = int64(583231 + len(b.users))
+		b.users[login] = id
+	}
+	return id
+}
+
+func (b *builder) user(login string) webhook.User {
+	var u webhook.User
+	id := b.userID(login)
+	b.fill(reflect.ValueOf(&u).Elem(), apiURL+"/users/"+login, htmlURL+"/"+login)
+	u.ID = id
+	u.NodeID = nodeID("U", id)
+	u.Login = login
+	u.Name = login
+	u.Username = login
+	u.Email = fmt.Sprintf("%d+%s@users.noreply.github.com", id, login)
+	u.AvatarURL = fmt.Sprintf("https://avatars.githubusercontent.com/u/%d?v=4", id)
+	u.Type = "User"
+	if login == b.org {
+		u.Type = "Organization"
+	}
+	return u
+}
+
+func (b *builder) repository() webhook.Repository {
+	var r webhook.Repository
+	b.fill(reflect.ValueOf(&r).Elem(), b.repoAPI(), b.repoHTML())
+	owner := b.owner
+	if b.org != "" {
+		owner = b.org
+	}
+	r.ID = 1296269
+	r.NodeID = nodeID("R", r.ID)
+	r.Name = b.name
+	r.FullName = b.fullName()
+	r.Owner = b.user(owner)
+	r.Description = "This your first repo!"
+	r.CloneURL = b.repoHTML() + ".git"
+	r.GitURL = "git://github.com/" + b.fullName() + ".git"
+	r.SSHURL = "git@github.com:" + b.fullName() + ".git"
+	r.SvnURL = b.repoHTML()
+	r.Visibility = "public"
+	r.HasIssues = true
+	r.HasWiki = true
+	r.AllowMergeCommit = true
+	r.AllowSquashMerge = true
+	r.AllowRebaseMerge = true
+	return r
+}
+
+// NewEvent builds the payload of the given event type, e.g. *webhook.PushEvent
+// for the "push" event. It returns nil if the event type is not supported.
+func NewEvent(event string, opts ...Option) webhook.Event {
+	if fn, ok := builders[event]; ok {
+		return fn(opts...)
+	}
+	return nil
+}
+
+// build populates the event payload.
+func build(e webhook.Event, action string, opts []Option) {
+	b := newBuilder(action, opts)
+	v := reflect.ValueOf(e).Elem()
+	b.fill(v, b.repoAPI(), b.repoHTML())
+	if b.org != "" {
+		org := b.user(b.org)
+		v.FieldByName("Organization").Set(reflect.ValueOf(&webhook.Organization{
+			AvatarURL:        org.AvatarURL,
+			EventsURL:        apiURL + "/orgs/" + b.org + "/events",
+			ID:               org.ID,
+			Login:            b.org,
+			MembersURL:       apiURL + "/orgs/" + b.org + "/members{/member}",
+			PublicMembersURL: apiURL + "/orgs/" + b.org + "/public_members{/member}",
+			ReposURL:         apiURL + "/orgs/" + b.org + "/repos",
+			URL:              apiURL + "/orgs/" + b.org,
+		}))
+	}
+	if b.installation != 0 {
+		v.FieldByName("Installation").Set(reflect.ValueOf(&webhook.Installation{
+			ID:     b.installation,
+			NodeID: nodeID("I", b.installation),
+		}))
+	}
+	b.finish(e)
+}
+
+// finish makes the members of the most common events consistent with each
+// other, after they were populated with defaults.
+func (b *builder) finish(e webhook.Event) {
+	switch e := e.(type) {
+	case *webhook.PushEvent:
+		b.push(e)
+	case *webhook.CreateEvent:
+		e.Ref, e.RefType = b.refName()
+		e.PusherType = "user"
+		e.Description = e.Repository.Description
+	case *webhook.DeleteEvent:
+		e.Ref, e.RefType = b.refName()
+		e.PusherType = "user"
+	case *webhook.PullRequestEvent:
+		e.Number = b.number
+		b.pullRequest(&e.PullRequest)
+	case *webhook.PullRequestReviewEvent:
+		b.pullRequest(&e.PullRequest)
+		e.Review.State = "approved"
+		e.Review.Body = "Looks good to me."
+		e.Review.AuthorAssociation = "MEMBER"
+		e.Review.HTMLURL = fmt.Sprintf("%s#pullrequestreview-%d", e.PullRequest.HTMLURL, e.Review.ID)
+		e.Review.PullRequestURL = e.PullRequest.URL
+	case *webhook.IssuesEvent:
+		b.issue(&e.Issue)
+	case *webhook.IssueCommentEvent:
+		b.issue(&e.Issue)
+		e.Comment.Body = "Me too"
+		e.Comment.HTMLURL = fmt.Sprintf("%s#issuecomment-%d", e.Issue.HTMLURL, e.Comment.ID)
+		e.Comment.IssueURL = e.Issue.URL
+		e.Comment.URL = fmt.Sprintf("%s/issues/comments/%d", b.repoAPI(), e.Comment.ID)
+	case *webhook.ReleaseEvent:
+		b.release(&e.Release)
+	case *webhook.PingEvent:
+		e.Zen = "Keep it logically awesome."
+		e.HookID = e.Hook.ID
+		e.Hook.Name = "web"
+		e.Hook.Type = "Repository"
+		e.Hook.Active = true
+		e.Hook.Events = []string{"*"}
+		e.Hook.URL = fmt.Sprintf("%s/hooks/%d", b.repoAPI(), e.Hook.ID)
+		e.Hook.PingURL = e.Hook.URL + "/pings"
+		e.Hook.TestURL = e.Hook.URL + "/test"
+	}
+}
+
+func (b *builder) refName() (ref, refType string) {
+	if tag := b.tag(); tag != "" {
+		return tag, "tag"
+	}
+	return b.branch(), "branch"
+}
+
+func (b *builder) push(e *webhook.PushEvent) {
+	pusher := b.user(b.sender)
+	author := webhook.User{Name: pusher.Login, Email: pusher.Email, Username: pusher.Login}
+	commit := webhook.PushCommit{
+		Added:     []string{},
+		Author:    author,
+		Committer: author,
+		Distinct:  true,
+		ID:        b.sha,
+		Message:   "Update " + strings.Join(b.paths, ", "),
+		Modified:  b.paths,
+		Removed:   []string{},
+		Timestamp: webhook.Time{Time: b.time},
+		TreeID:    sha(b.sha + "^{tree}"),
+		URL:       b.repoHTML() + "/commit/" + b.sha,
+	}
+	e.Ref = b.ref
+	e.Pusher = webhook.User{Name: pusher.Login, Email: pusher.Email}
+	e.HeadCommit = commit
+	e.Commits = []webhook.PushCommit{commit}
+	if b.tag() != "" {
+		e.Before = zeroSHA
+		e.Created = true
+		e.Commits = nil
+		e.Compare = b.repoHTML() + "/compare/" + b.tag()
+		return
+	}
+	e.Compare = b.repoHTML() + "/compare/" + compareSHA(e.Before) + "..." + compareSHA(e.After)
+}
+
+func (b *builder) pullRequest(pr *webhook.PullRequest) {
+	head := b.branch()
+	if head == b.defaultBranch {
+		head = "patch-1"
+	}
+	pr.Number = b.number
+	pr.State = "open"
+	pr.Title = "Update " + b.paths[0]
+	pr.Body = "This is a pretty simple change that we need to pull into " + b.defaultBranch + "."
+	pr.AuthorAssociation = "MEMBER"
+	pr.MergeableState = "clean"
+	mergeable := true
+	pr.Mergeable, pr.Rebaseable = &mergeable, &mergeable
+	pr.Commits = 1
+	pr.ChangedFiles = int64(len(b.paths))
+	pr.URL = fmt.Sprintf("%s/pulls/%d", b.repoAPI(), b.number)
+	pr.HTMLURL = fmt.Sprintf("%s/pull/%d", b.repoHTML(), b.number)
+	pr.IssueURL = fmt.Sprintf("%s/issues/%d", b.repoAPI(), b.number)
+	pr.DiffURL = pr.HTMLURL + ".diff"
+	pr.PatchURL = pr.HTMLURL + ".patch"
+	pr.CommitsURL = pr.URL + "/commits"
+	pr.ReviewCommentsURL = pr.URL + "/comments"
+	pr.ReviewCommentURL = b.repoAPI() + "/pulls/comments{/number}"
+	pr.CommentsURL = pr.IssueURL + "/comments"
+	pr.StatusesURL = b.repoAPI() + "/statuses/" + b.sha
+	pr.Head.Ref = head
+	pr.Head.Label = b.owner + ":" + head
+	pr.Base.Ref = b.defaultBranch
+	pr.Base.Label = b.owner + ":" + b.defaultBranch
+	pr.Base.SHA = b.before
+}
+
+func (b *builder) issue(issue *webhook.Issue) {
+	issue.Number = b.number
+	issue.State = "open"
+	issue.Title = "Spelling error in the README file"
+	issue.Body = "It looks like you accidentally spelled 'commit' with two 't's."
+	issue.URL = fmt.Sprintf("%s/issues/%d", b.repoAPI(), b.number)
+	issue.HTMLURL = fmt.Sprintf("%s/issues/%d", b.repoHTML(), b.number)
+	issue.CommentsURL = issue.URL + "/comments"
+	issue.EventsURL = issue.URL + "/events"
+	issue.LabelsURL = issue.URL + "/labels{/name}"
+}
+
+func (b *builder) release(r *webhook.Release) {
+	tag := b.tag()
+	if tag == "" {
+		tag = "v1.0.0"
+	}
+	r.TagName = tag
+	r.Name = &tag
+	r.TargetCommitish = b.branch()
+	r.URL = fmt.Sprintf("%s/releases/%d", b.repoAPI(), r.ID)
+	r.HTMLURL = b.repoHTML() + "/releases/tag/" + tag
+	r.AssetsURL = r.URL + "/assets"
+	r.UploadURL = fmt.Sprintf("https://uploads.github.com/repos/%s/releases/%d/assets{?name,label}", b.fullName(), r.ID)
+	r.TarballURL = b.repoAPI() + "/tarball/" + tag
+	r.ZipballURL = b.repoAPI() + "/zipball/" + tag
+}
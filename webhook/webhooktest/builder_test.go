@@ -0,0 +1,128 @@
+package webhooktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+func TestNewEvent(t *testing.T) {
+	for event := range builders {
+		e := NewEvent(event, Repo("o/r"), Sender("alice"), Org("o"), Installation(42))
+		if e == nil {
+			t.Errorf("want non-nil payload (event=%s)", event)
+			continue
+		}
+		if name := e.EventName(); name != event {
+			t.Errorf("want EventName()=%s; got %s", event, name)
+		}
+		if repo := e.GetRepository(); repo != nil {
+			if repo.FullName != "o/r" || repo.Owner.Login != "o" || repo.HTMLURL != "https://github.com/o/r" {
+				t.Errorf("want o/r repository; got %s %s %s (event=%s)", repo.FullName, repo.Owner.Login, repo.HTMLURL, event)
+			}
+		}
+		if sender := e.GetSender(); sender != nil && sender.Login != "alice" {
+			t.Errorf("want Sender.Login=alice; got %s (event=%s)", sender.Login, event)
+		}
+		if org := e.GetOrganization(); org == nil || org.Login != "o" {
+			t.Errorf("want Organization.Login=o; got %+v (event=%s)", org, event)
+		}
+		if inst := e.GetInstallation(); inst == nil || inst.ID != 42 {
+			t.Errorf("want Installation.ID=42; got %+v (event=%s)", inst, event)
+		}
+		p, err := json.Marshal(e)
+		if err != nil {
+			t.Errorf("Marshal()=%v (event=%s)", err, event)
+			continue
+		}
+		v := reflect.New(reflect.TypeOf(e).Elem()).Interface()
+		if err := json.Unmarshal(p, v); err != nil {
+			t.Errorf("Unmarshal()=%v (event=%s)", err, event)
+		}
+	}
+	if e := NewEvent("unknown"); e != nil {
+		t.Errorf("want nil payload for unknown event; got %T", e)
+	}
+}
+
+func TestNewPushEvent(t *testing.T) {
+	e := NewPushEvent(Repo("o/r"), Ref("feature"), Paths("services/api/main.go", "go.mod"))
+	switch {
+	case e.Ref != "refs/heads/feature" || e.Branch() != "feature":
+		t.Errorf("want feature branch; got %s", e.Ref)
+	case e.IsDefaultBranch():
+		t.Error("want IsDefaultBranch()=false")
+	case e.HeadCommit.ID != e.After || len(e.Commits) != 1 || e.Commits[0].ID != e.After:
+		t.Errorf("want head commit to be %s; got %+v", e.After, e.HeadCommit)
+	case !reflect.DeepEqual(e.ChangedPaths(), []string{"go.mod", "services/api/main.go"}):
+		t.Errorf("want changed paths; got %v", e.ChangedPaths())
+	case !strings.HasPrefix(e.CompareURL(), "https://github.com/o/r/compare/"+compareSHA(e.Before)):
+		t.Errorf("want compare URL for o/r; got %s", e.CompareURL())
+	case e.Pusher.Name != "o" || e.HeadCommit.Author.Username != "o":
+		t.Errorf("want pusher o; got %+v", e.Pusher)
+	}
+	if d := NewPushEvent(); !d.IsDefaultBranch() || d.Repository.FullName != "octocat/hello-world" {
+		t.Errorf("want push to default branch of octocat/hello-world; got %s %s", d.Ref, d.Repository.FullName)
+	}
+	if short := NewPushEvent(SHA("abc123"), Before("def")); !strings.HasSuffix(short.Compare, "/compare/def...abc123") {
+		t.Errorf("want compare URL for short SHAs; got %s", short.Compare)
+	}
+	tag := NewPushEvent(Tag("v1.2.0"))
+	if !tag.IsTag() || tag.Tag() != "v1.2.0" || !tag.Created || len(webhook.Derive(tag)) != 1 {
+		t.Errorf("want tag push; got %+v", tag)
+	}
+}
+
+func TestNewRefEvents(t *testing.T) {
+	create := NewCreateEvent(Ref("feature"))
+	if !create.IsBranchCreation() || create.Branch() != "feature" {
+		t.Errorf("want feature branch creation; got %s %s", create.RefType, create.Ref)
+	}
+	del := NewDeleteEvent(Tag("v1.0.0"))
+	if !del.IsTag() || del.Tag() != "v1.0.0" {
+		t.Errorf("want v1.0.0 tag deletion; got %s %s", del.RefType, del.Ref)
+	}
+}
+
+func TestNewPullRequestEvent(t *testing.T) {
+	e := NewPullRequestEvent(Repo("o/r"), Number(7), SHA("abc123"))
+	pr := e.PullRequest
+	switch {
+	case e.Action != "opened" || e.Number != 7 || pr.Number != 7:
+		t.Errorf("want opened #7; got %s #%d", e.Action, pr.Number)
+	case pr.HTMLURL != "https://github.com/o/r/pull/7" || pr.URL != "https://api.github.com/repos/o/r/pulls/7":
+		t.Errorf("want #7 URLs; got %s %s", pr.HTMLURL, pr.URL)
+	case pr.Head.SHA != "abc123" || pr.Head.Ref == pr.Base.Ref || pr.Base.Ref != "main":
+		t.Errorf("want head abc123 and base main; got %+v %+v", pr.Head, pr.Base)
+	case pr.Head.Repo.FullName != "o/r" || pr.User.Login != "o":
+		t.Errorf("want o/r head repository; got %s", pr.Head.Repo.FullName)
+	}
+	merged := NewPullRequestEvent(Action("closed"))
+	merged.PullRequest.Merged = true
+	if d := webhook.Derive(merged); len(d) != 1 || d[0].EventName() != "pull_request_merged" {
+		t.Errorf("want pull_request_merged derived event; got %v", d)
+	}
+	review := NewPullRequestReviewEvent()
+	if d := webhook.Derive(review); len(d) != 1 || d[0].EventName() != "review_approved" {
+		t.Errorf("want review_approved derived event; got %v", d)
+	}
+	release := NewReleaseEvent(Tag("v2.0.0"))
+	if release.Release.TagName != "v2.0.0" || len(webhook.Derive(release)) != 1 {
+		t.Errorf("want published v2.0.0 release; got %s %s", release.Action, release.Release.TagName)
+	}
+}
+
+func TestDeliverBuiltEvents(t *testing.T) {
+	h := NewDetailHandler()
+	ts := NewServer(secret, webhook.New(secret, h))
+	defer ts.Close()
+	for event := range builders {
+		resp, err := ts.DeliverEvent(NewEvent(event))
+		AssertStatus(t, resp, err, http.StatusOK)
+		AssertRecorded(t, h.Recorder, event, 1)
+	}
+}
@@ -0,0 +1,361 @@
+// Created by go generate; DO NOT EDIT
+
+package webhooktest
+
+import "github.com/rjeczalik/gh/webhook"
+
+// NewBranchProtectionRuleEvent builds the branch_protection_rule event payload.
+// The default action is "created".
+func NewBranchProtectionRuleEvent(opts ...Option) *webhook.BranchProtectionRuleEvent {
+	e := &webhook.BranchProtectionRuleEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewCommitCommentEvent builds the commit_comment event payload.
+// The default action is "created".
+func NewCommitCommentEvent(opts ...Option) *webhook.CommitCommentEvent {
+	e := &webhook.CommitCommentEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewCreateEvent builds the create event payload.
+func NewCreateEvent(opts ...Option) *webhook.CreateEvent {
+	e := &webhook.CreateEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewDeleteEvent builds the delete event payload.
+func NewDeleteEvent(opts ...Option) *webhook.DeleteEvent {
+	e := &webhook.DeleteEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewDeployKeyEvent builds the deploy_key event payload.
+// The default action is "created".
+func NewDeployKeyEvent(opts ...Option) *webhook.DeployKeyEvent {
+	e := &webhook.DeployKeyEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewDeploymentEvent builds the deployment event payload.
+func NewDeploymentEvent(opts ...Option) *webhook.DeploymentEvent {
+	e := &webhook.DeploymentEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewDeploymentStatusEvent builds the deployment_status event payload.
+func NewDeploymentStatusEvent(opts ...Option) *webhook.DeploymentStatusEvent {
+	e := &webhook.DeploymentStatusEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewDownloadEvent builds the download event payload.
+func NewDownloadEvent(opts ...Option) *webhook.DownloadEvent {
+	e := &webhook.DownloadEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewFollowEvent builds the follow event payload.
+func NewFollowEvent(opts ...Option) *webhook.FollowEvent {
+	e := &webhook.FollowEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewForkEvent builds the fork event payload.
+func NewForkEvent(opts ...Option) *webhook.ForkEvent {
+	e := &webhook.ForkEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewForkApplyEvent builds the fork_apply event payload.
+func NewForkApplyEvent(opts ...Option) *webhook.ForkApplyEvent {
+	e := &webhook.ForkApplyEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewGistEvent builds the gist event payload.
+// The default action is "create".
+func NewGistEvent(opts ...Option) *webhook.GistEvent {
+	e := &webhook.GistEvent{}
+	build(e, "create", opts)
+	return e
+}
+
+// NewGollumEvent builds the gollum event payload.
+func NewGollumEvent(opts ...Option) *webhook.GollumEvent {
+	e := &webhook.GollumEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewIssueCommentEvent builds the issue_comment event payload.
+// The default action is "created".
+func NewIssueCommentEvent(opts ...Option) *webhook.IssueCommentEvent {
+	e := &webhook.IssueCommentEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewIssuesEvent builds the issues event payload.
+// The default action is "opened".
+func NewIssuesEvent(opts ...Option) *webhook.IssuesEvent {
+	e := &webhook.IssuesEvent{}
+	build(e, "opened", opts)
+	return e
+}
+
+// NewLabelEvent builds the label event payload.
+// The default action is "edited".
+func NewLabelEvent(opts ...Option) *webhook.LabelEvent {
+	e := &webhook.LabelEvent{}
+	build(e, "edited", opts)
+	return e
+}
+
+// NewMemberEvent builds the member event payload.
+// The default action is "added".
+func NewMemberEvent(opts ...Option) *webhook.MemberEvent {
+	e := &webhook.MemberEvent{}
+	build(e, "added", opts)
+	return e
+}
+
+// NewMembershipEvent builds the membership event payload.
+// The default action is "added".
+func NewMembershipEvent(opts ...Option) *webhook.MembershipEvent {
+	e := &webhook.MembershipEvent{}
+	build(e, "added", opts)
+	return e
+}
+
+// NewMergeGroupEvent builds the merge_group event payload.
+// The default action is "checks_requested".
+func NewMergeGroupEvent(opts ...Option) *webhook.MergeGroupEvent {
+	e := &webhook.MergeGroupEvent{}
+	build(e, "checks_requested", opts)
+	return e
+}
+
+// NewMetaEvent builds the meta event payload.
+// The default action is "deleted".
+func NewMetaEvent(opts ...Option) *webhook.MetaEvent {
+	e := &webhook.MetaEvent{}
+	build(e, "deleted", opts)
+	return e
+}
+
+// NewOrgBlockEvent builds the org_block event payload.
+// The default action is "blocked".
+func NewOrgBlockEvent(opts ...Option) *webhook.OrgBlockEvent {
+	e := &webhook.OrgBlockEvent{}
+	build(e, "blocked", opts)
+	return e
+}
+
+// NewOrganizationEvent builds the organization event payload.
+// The default action is "member_added".
+func NewOrganizationEvent(opts ...Option) *webhook.OrganizationEvent {
+	e := &webhook.OrganizationEvent{}
+	build(e, "member_added", opts)
+	return e
+}
+
+// NewPackageEvent builds the package event payload.
+// The default action is "published".
+func NewPackageEvent(opts ...Option) *webhook.PackageEvent {
+	e := &webhook.PackageEvent{}
+	build(e, "published", opts)
+	return e
+}
+
+// NewPageBuildEvent builds the page_build event payload.
+func NewPageBuildEvent(opts ...Option) *webhook.PageBuildEvent {
+	e := &webhook.PageBuildEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewPingEvent builds the ping event payload.
+func NewPingEvent(opts ...Option) *webhook.PingEvent {
+	e := &webhook.PingEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewPublicEvent builds the public event payload.
+func NewPublicEvent(opts ...Option) *webhook.PublicEvent {
+	e := &webhook.PublicEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewPullRequestEvent builds the pull_request event payload.
+// The default action is "opened".
+func NewPullRequestEvent(opts ...Option) *webhook.PullRequestEvent {
+	e := &webhook.PullRequestEvent{}
+	build(e, "opened", opts)
+	return e
+}
+
+// NewPullRequestReviewEvent builds the pull_request_review event payload.
+// The default action is "submitted".
+func NewPullRequestReviewEvent(opts ...Option) *webhook.PullRequestReviewEvent {
+	e := &webhook.PullRequestReviewEvent{}
+	build(e, "submitted", opts)
+	return e
+}
+
+// NewPullRequestReviewCommentEvent builds the pull_request_review_comment event payload.
+// The default action is "created".
+func NewPullRequestReviewCommentEvent(opts ...Option) *webhook.PullRequestReviewCommentEvent {
+	e := &webhook.PullRequestReviewCommentEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewPushEvent builds the push event payload.
+func NewPushEvent(opts ...Option) *webhook.PushEvent {
+	e := &webhook.PushEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewRegistryPackageEvent builds the registry_package event payload.
+// The default action is "published".
+func NewRegistryPackageEvent(opts ...Option) *webhook.RegistryPackageEvent {
+	e := &webhook.RegistryPackageEvent{}
+	build(e, "published", opts)
+	return e
+}
+
+// NewReleaseEvent builds the release event payload.
+// The default action is "published".
+func NewReleaseEvent(opts ...Option) *webhook.ReleaseEvent {
+	e := &webhook.ReleaseEvent{}
+	build(e, "published", opts)
+	return e
+}
+
+// NewRepositoryEvent builds the repository event payload.
+// The default action is "created".
+func NewRepositoryEvent(opts ...Option) *webhook.RepositoryEvent {
+	e := &webhook.RepositoryEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewRepositoryDispatchEvent builds the repository_dispatch event payload.
+// The default action is "on-demand-test".
+func NewRepositoryDispatchEvent(opts ...Option) *webhook.RepositoryDispatchEvent {
+	e := &webhook.RepositoryDispatchEvent{}
+	build(e, "on-demand-test", opts)
+	return e
+}
+
+// NewRepositoryImportEvent builds the repository_import event payload.
+func NewRepositoryImportEvent(opts ...Option) *webhook.RepositoryImportEvent {
+	e := &webhook.RepositoryImportEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewSponsorshipEvent builds the sponsorship event payload.
+// The default action is "created".
+func NewSponsorshipEvent(opts ...Option) *webhook.SponsorshipEvent {
+	e := &webhook.SponsorshipEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewStarEvent builds the star event payload.
+// The default action is "created".
+func NewStarEvent(opts ...Option) *webhook.StarEvent {
+	e := &webhook.StarEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewStatusEvent builds the status event payload.
+func NewStatusEvent(opts ...Option) *webhook.StatusEvent {
+	e := &webhook.StatusEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewTeamEvent builds the team event payload.
+// The default action is "created".
+func NewTeamEvent(opts ...Option) *webhook.TeamEvent {
+	e := &webhook.TeamEvent{}
+	build(e, "created", opts)
+	return e
+}
+
+// NewTeamAddEvent builds the team_add event payload.
+func NewTeamAddEvent(opts ...Option) *webhook.TeamAddEvent {
+	e := &webhook.TeamAddEvent{}
+	build(e, "", opts)
+	return e
+}
+
+// NewWatchEvent builds the watch event payload.
+// The default action is "started".
+func NewWatchEvent(opts ...Option) *webhook.WatchEvent {
+	e := &webhook.WatchEvent{}
+	build(e, "started", opts)
+	return e
+}
+
+var builders = map[string]func(...Option) webhook.Event{
+	"branch_protection_rule":      func(opts ...Option) webhook.Event { return NewBranchProtectionRuleEvent(opts...) },
+	"commit_comment":              func(opts ...Option) webhook.Event { return NewCommitCommentEvent(opts...) },
+	"create":                      func(opts ...Option) webhook.Event { return NewCreateEvent(opts...) },
+	"delete":                      func(opts ...Option) webhook.Event { return NewDeleteEvent(opts...) },
+	"deploy_key":                  func(opts ...Option) webhook.Event { return NewDeployKeyEvent(opts...) },
+	"deployment":                  func(opts ...Option) webhook.Event { return NewDeploymentEvent(opts...) },
+	"deployment_status":           func(opts ...Option) webhook.Event { return NewDeploymentStatusEvent(opts...) },
+	"download":                    func(opts ...Option) webhook.Event { return NewDownloadEvent(opts...) },
+	"follow":                      func(opts ...Option) webhook.Event { return NewFollowEvent(opts...) },
+	"fork":                        func(opts ...Option) webhook.Event { return NewForkEvent(opts...) },
+	"fork_apply":                  func(opts ...Option) webhook.Event { return NewForkApplyEvent(opts...) },
+	"gist":                        func(opts ...Option) webhook.Event { return NewGistEvent(opts...) },
+	"gollum":                      func(opts ...Option) webhook.Event { return NewGollumEvent(opts...) },
+	"issue_comment":               func(opts ...Option) webhook.Event { return NewIssueCommentEvent(opts...) },
+	"issues":                      func(opts ...Option) webhook.Event { return NewIssuesEvent(opts...) },
+	"label":                       func(opts ...Option) webhook.Event { return NewLabelEvent(opts...) },
+	"member":                      func(opts ...Option) webhook.Event { return NewMemberEvent(opts...) },
+	"membership":                  func(opts ...Option) webhook.Event { return NewMembershipEvent(opts...) },
+	"merge_group":                 func(opts ...Option) webhook.Event { return NewMergeGroupEvent(opts...) },
+	"meta":                        func(opts ...Option) webhook.Event { return NewMetaEvent(opts...) },
+	"org_block":                   func(opts ...Option) webhook.Event { return NewOrgBlockEvent(opts...) },
+	"organization":                func(opts ...Option) webhook.Event { return NewOrganizationEvent(opts...) },
+	"package":                     func(opts ...Option) webhook.Event { return NewPackageEvent(opts...) },
+	"page_build":                  func(opts ...Option) webhook.Event { return NewPageBuildEvent(opts...) },
+	"ping":                        func(opts ...Option) webhook.Event { return NewPingEvent(opts...) },
+	"public":                      func(opts ...Option) webhook.Event { return NewPublicEvent(opts...) },
+	"pull_request":                func(opts ...Option) webhook.Event { return NewPullRequestEvent(opts...) },
+	"pull_request_review":         func(opts ...Option) webhook.Event { return NewPullRequestReviewEvent(opts...) },
+	"pull_request_review_comment": func(opts ...Option) webhook.Event { return NewPullRequestReviewCommentEvent(opts...) },
+	"push":                        func(opts ...Option) webhook.Event { return NewPushEvent(opts...) },
+	"registry_package":            func(opts ...Option) webhook.Event { return NewRegistryPackageEvent(opts...) },
+	"release":                     func(opts ...Option) webhook.Event { return NewReleaseEvent(opts...) },
+	"repository":                  func(opts ...Option) webhook.Event { return NewRepositoryEvent(opts...) },
+	"repository_dispatch":         func(opts ...Option) webhook.Event { return NewRepositoryDispatchEvent(opts...) },
+	"repository_import":           func(opts ...Option) webhook.Event { return NewRepositoryImportEvent(opts...) },
+	"sponsorship":                 func(opts ...Option) webhook.Event { return NewSponsorshipEvent(opts...) },
+	"star":                        func(opts ...Option) webhook.Event { return NewStarEvent(opts...) },
+	"status":                      func(opts ...Option) webhook.Event { return NewStatusEvent(opts...) },
+	"team":                        func(opts ...Option) webhook.Event { return NewTeamEvent(opts...) },
+	"team_add":                    func(opts ...Option) webhook.Event { return NewTeamAddEvent(opts...) },
+	"watch":                       func(opts ...Option) webhook.Event { return NewWatchEvent(opts...) },
+}
@@ -0,0 +1,237 @@
+// Created by go generate; DO NOT EDIT
+
+package webhooktest
+
+import "github.com/rjeczalik/gh/webhook"
+
+// DetailHandler is a webhook service, which handles each event type with
+// the dedicated method and records it.
+type DetailHandler struct {
+	Recorder *Recorder
+}
+
+// NewDetailHandler creates new DetailHandler with an empty recorder.
+func NewDetailHandler() *DetailHandler {
+	return &DetailHandler{Recorder: &Recorder{}}
+}
+
+// BranchProtectionRule records the branch_protection_rule event.
+func (dh *DetailHandler) BranchProtectionRule(e *webhook.BranchProtectionRuleEvent) {
+	dh.Recorder.Record("branch_protection_rule", e)
+}
+
+// CommitComment records the commit_comment event.
+func (dh *DetailHandler) CommitComment(e *webhook.CommitCommentEvent) {
+	dh.Recorder.Record("commit_comment", e)
+}
+
+// Create records the create event.
+func (dh *DetailHandler) Create(e *webhook.CreateEvent) {
+	dh.Recorder.Record("create", e)
+}
+
+// Delete records the delete event.
+func (dh *DetailHandler) Delete(e *webhook.DeleteEvent) {
+	dh.Recorder.Record("delete", e)
+}
+
+// DeployKey records the deploy_key event.
+func (dh *DetailHandler) DeployKey(e *webhook.DeployKeyEvent) {
+	dh.Recorder.Record("deploy_key", e)
+}
+
+// Deployment records the deployment event.
+func (dh *DetailHandler) Deployment(e *webhook.DeploymentEvent) {
+	dh.Recorder.Record("deployment", e)
+}
+
+// DeploymentStatus records the deployment_status event.
+func (dh *DetailHandler) DeploymentStatus(e *webhook.DeploymentStatusEvent) {
+	dh.Recorder.Record("deployment_status", e)
+}
+
+// Download records the download event.
+func (dh *DetailHandler) Download(e *webhook.DownloadEvent) {
+	dh.Recorder.Record("download", e)
+}
+
+// Follow records the follow event.
+func (dh *DetailHandler) Follow(e *webhook.FollowEvent) {
+	dh.Recorder.Record("follow", e)
+}
+
+// Fork records the fork event.
+func (dh *DetailHandler) Fork(e *webhook.ForkEvent) {
+	dh.Recorder.Record("fork", e)
+}
+
+// ForkApply records the fork_apply event.
+func (dh *DetailHandler) ForkApply(e *webhook.ForkApplyEvent) {
+	dh.Recorder.Record("fork_apply", e)
+}
+
+// Gist records the gist event.
+func (dh *DetailHandler) Gist(e *webhook.GistEvent) {
+	dh.Recorder.Record("gist", e)
+}
+
+// Gollum records the gollum event.
+func (dh *DetailHandler) Gollum(e *webhook.GollumEvent) {
+	dh.Recorder.Record("gollum", e)
+}
+
+// IssueComment records the issue_comment event.
+func (dh *DetailHandler) IssueComment(e *webhook.IssueCommentEvent) {
+	dh.Recorder.Record("issue_comment", e)
+}
+
+// Issues records the issues event.
+func (dh *DetailHandler) Issues(e *webhook.IssuesEvent) {
+	dh.Recorder.Record("issues", e)
+}
+
+// Label records the label event.
+func (dh *DetailHandler) Label(e *webhook.LabelEvent) {
+	dh.Recorder.Record("label", e)
+}
+
+// Member records the member event.
+func (dh *DetailHandler) Member(e *webhook.MemberEvent) {
+	dh.Recorder.Record("member", e)
+}
+
+// Membership records the membership event.
+func (dh *DetailHandler) Membership(e *webhook.MembershipEvent) {
+	dh.Recorder.Record("membership", e)
+}
+
+// MergeGroup records the merge_group event.
+func (dh *DetailHandler) MergeGroup(e *webhook.MergeGroupEvent) {
+	dh.Recorder.Record("merge_group", e)
+}
+
+// Meta records the meta event.
+func (dh *DetailHandler) Meta(e *webhook.MetaEvent) {
+	dh.Recorder.Record("meta", e)
+}
+
+// OrgBlock records the org_block event.
+func (dh *DetailHandler) OrgBlock(e *webhook.OrgBlockEvent) {
+	dh.Recorder.Record("org_block", e)
+}
+
+// Organization records the organization event.
+func (dh *DetailHandler) Organization(e *webhook.OrganizationEvent) {
+	dh.Recorder.Record("organization", e)
+}
+
+// Package records the package event.
+func (dh *DetailHandler) Package(e *webhook.PackageEvent) {
+	dh.Recorder.Record("package", e)
+}
+
+// PageBuild records the page_build event.
+func (dh *DetailHandler) PageBuild(e *webhook.PageBuildEvent) {
+	dh.Recorder.Record("page_build", e)
+}
+
+// Ping records the ping event.
+func (dh *DetailHandler) Ping(e *webhook.PingEvent) {
+	dh.Recorder.Record("ping", e)
+}
+
+// Public records the public event.
+func (dh *DetailHandler) Public(e *webhook.PublicEvent) {
+	dh.Recorder.Record("public", e)
+}
+
+// PullRequest records the pull_request event.
+func (dh *DetailHandler) PullRequest(e *webhook.PullRequestEvent) {
+	dh.Recorder.Record("pull_request", e)
+}
+
+// PullRequestReview records the pull_request_review event.
+func (dh *DetailHandler) PullRequestReview(e *webhook.PullRequestReviewEvent) {
+	dh.Recorder.Record("pull_request_review", e)
+}
+
+// PullRequestReviewComment records the pull_request_review_comment event.
+func (dh *DetailHandler) PullRequestReviewComment(e *webhook.PullRequestReviewCommentEvent) {
+	dh.Recorder.Record("pull_request_review_comment", e)
+}
+
+// Push records the push event.
+func (dh *DetailHandler) Push(e *webhook.PushEvent) {
+	dh.Recorder.Record("push", e)
+}
+
+// RegistryPackage records the registry_package event.
+func (dh *DetailHandler) RegistryPackage(e *webhook.RegistryPackageEvent) {
+	dh.Recorder.Record("registry_package", e)
+}
+
+// Release records the release event.
+func (dh *DetailHandler) Release(e *webhook.ReleaseEvent) {
+	dh.Recorder.Record("release", e)
+}
+
+// Repository records the repository event.
+func (dh *DetailHandler) Repository(e *webhook.RepositoryEvent) {
+	dh.Recorder.Record("repository", e)
+}
+
+// RepositoryDispatch records the repository_dispatch event.
+func (dh *DetailHandler) RepositoryDispatch(e *webhook.RepositoryDispatchEvent) {
+	dh.Recorder.Record("repository_dispatch", e)
+}
+
+// RepositoryImport records the repository_import event.
+func (dh *DetailHandler) RepositoryImport(e *webhook.RepositoryImportEvent) {
+	dh.Recorder.Record("repository_import", e)
+}
+
+// Sponsorship records the sponsorship event.
+func (dh *DetailHandler) Sponsorship(e *webhook.SponsorshipEvent) {
+	dh.Recorder.Record("sponsorship", e)
+}
+
+// Star records the star event.
+func (dh *DetailHandler) Star(e *webhook.StarEvent) {
+	dh.Recorder.Record("star", e)
+}
+
+// Status records the status event.
+func (dh *DetailHandler) Status(e *webhook.StatusEvent) {
+	dh.Recorder.Record("status", e)
+}
+
+// Team records the team event.
+func (dh *DetailHandler) Team(e *webhook.TeamEvent) {
+	dh.Recorder.Record("team", e)
+}
+
+// TeamAdd records the team_add event.
+func (dh *DetailHandler) TeamAdd(e *webhook.TeamAddEvent) {
+	dh.Recorder.Record("team_add", e)
+}
+
+// Watch records the watch event.
+func (dh *DetailHandler) Watch(e *webhook.WatchEvent) {
+	dh.Recorder.Record("watch", e)
+}
+
+// BlanketHandler is a webhook service, which handles all the events with
+// a single method and records them.
+type BlanketHandler struct {
+	Recorder *Recorder
+}
+
+// NewBlanketHandler creates new BlanketHandler with an empty recorder.
+func NewBlanketHandler() *BlanketHandler {
+	return &BlanketHandler{Recorder: &Recorder{}}
+}
+
+// All records the event.
+func (bh *BlanketHandler) All(event string, payload interface{}) {
+	bh.Recorder.Record(event, payload)
+}
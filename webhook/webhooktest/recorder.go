@@ -0,0 +1,144 @@
+package webhooktest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Timeout is the duration AssertRecorded waits for the events to be
+// handled. Webhook handler dispatches events asynchronously, thus they
+// may be recorded after the response was already received.
+var Timeout = 5 * time.Second
+
+// Record is a single event handled by a recording handler.
+type Record struct {
+	Event   string      // name of the event type
+	Payload interface{} // the event struct
+}
+
+// Recorder keeps track of the handled events. It is safe for concurrent use.
+// The zero value is ready to use.
+type Recorder struct {
+	mu      sync.Mutex
+	records []Record
+	notify  chan struct{}
+}
+
+// Record adds the event to the recorder. It can be called by custom handler
+// services in order to use the assertion helpers with them.
+func (r *Recorder) Record(event string, payload interface{}) {
+	r.mu.Lock()
+	r.records = append(r.records, Record{Event: event, Payload: payload})
+	if r.notify != nil {
+		close(r.notify)
+		r.notify = nil
+	}
+	r.mu.Unlock()
+}
+
+// Records gives a copy of all the recorded events, in the order they were
+// handled.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Record(nil), r.records...)
+}
+
+// Count gives the number of recorded events of the given type. If event is
+// empty, all the recorded events are counted.
+func (r *Recorder) Count(event string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count(event)
+}
+
+// Wait blocks until at least n events of the given type are recorded or until
+// the timeout passes. It reports whether the events were recorded.
+func (r *Recorder) Wait(event string, n int, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for {
+		r.mu.Lock()
+		if r.count(event) >= n {
+			r.mu.Unlock()
+			return true
+		}
+		if r.notify == nil {
+			r.notify = make(chan struct{})
+		}
+		notify := r.notify
+		r.mu.Unlock()
+		select {
+		case <-notify:
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// Reset removes all the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.records = nil
+	r.mu.Unlock()
+}
+
+func (r *Recorder) count(event string) int {
+	if event == "" {
+		return len(r.records)
+	}
+	n := 0
+	for _, rec := range r.records {
+		if rec.Event == event {
+			n++
+		}
+	}
+	return n
+}
+
+// AssertStatus fails the test if the request failed or if its response has
+// a different status code than the expected one.
+func AssertStatus(t testing.TB, resp *http.Response, err error, code int) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("delivery failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != code {
+		body, _ := ioutil.ReadAll(resp.Body)
+		t.Errorf("want StatusCode=%d; got %d: %s", code, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// AssertBody fails the test if the request failed or if its response body
+// does not contain the given string.
+func AssertBody(t testing.TB, resp *http.Response, err error, substr string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("delivery failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body failed: %v", err)
+	}
+	if !strings.Contains(string(body), substr) {
+		t.Errorf("want body to contain %q; got %q", substr, body)
+	}
+}
+
+// AssertRecorded fails the test if the recorder does not record exactly n
+// events of the given type within the Timeout.
+func AssertRecorded(t testing.TB, r *Recorder, event string, n int) {
+	t.Helper()
+	if n != 0 && !r.Wait(event, n, Timeout) {
+		t.Errorf("want %d %q events recorded; got %d", n, event, r.Count(event))
+		return
+	}
+	if count := r.Count(event); count != n {
+		t.Errorf("want %d %q events recorded; got %d", n, event, count)
+	}
+}
@@ -0,0 +1,211 @@
+// Package webhooktest provides utilities for testing GitHub webhook services.
+//
+// The Client delivers events to a webhook in the same way GitHub does - each
+// request carries the X-GitHub-Event and X-GitHub-Delivery headers and its
+// body is signed with the shared secret. The Server starts a test HTTP server
+// for the given handler and delivers events to it.
+//
+// The DetailHandler and BlanketHandler are webhook services, which record
+// each event they handle, so tests can assert on what was dispatched.
+//
+// Example
+//
+//   func TestService(t *testing.T) {
+//   	h := webhooktest.NewDetailHandler()
+//   	ts := webhooktest.NewServer("secret", webhook.New("secret", h))
+//   	defer ts.Close()
+//
+//   	resp, err := ts.DeliverFile("testdata/push.json")
+//   	webhooktest.AssertStatus(t, resp, err, http.StatusOK)
+//   	webhooktest.AssertRecorded(t, h.Recorder, "push", 1)
+//   }
+package webhooktest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+func hexDigest(h func() hash.Hash, secret string, p []byte) string {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(p)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Signature gives the value of the X-Hub-Signature header for the given
+// payload body, e.g. "sha1=5f1ecb...".
+func Signature(secret string, body []byte) string {
+	return "sha1=" + hexDigest(sha1.New, secret, body)
+}
+
+// Signature256 gives the value of the X-Hub-Signature-256 header for the given
+// payload body, e.g. "sha256=7d38cd...".
+func Signature256(secret string, body []byte) string {
+	return "sha256=" + hexDigest(sha256.New, secret, body)
+}
+
+// DeliveryID generates random GUID for the X-GitHub-Delivery header.
+func DeliveryID() string {
+	var p [16]byte
+	if _, err := rand.Read(p[:]); err != nil {
+		panic("webhooktest: unable to generate delivery ID: " + err.Error())
+	}
+	p[6] = (p[6] & 0x0f) | 0x40
+	p[8] = (p[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", p[:4], p[4:6], p[6:8], p[8:10], p[10:])
+}
+
+// EventName gives the name of the event the JSON fixture file stores,
+// following the naming convention of the webhook's testdata and the files
+// written by webhook.Dumper - <event>[-<suffix>].json.
+func EventName(file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), ".json")
+	if i := strings.IndexByte(name, '-'); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+// Marshal gives the JSON body of the payload. The payload is either already
+// encoded body of the []byte, json.RawMessage or string type, or a value,
+// which is encoded with json.Marshal.
+func Marshal(payload interface{}) ([]byte, error) {
+	switch p := payload.(type) {
+	case []byte:
+		return p, nil
+	case json.RawMessage:
+		return p, nil
+	case string:
+		return []byte(p), nil
+	default:
+		return json.Marshal(payload)
+	}
+}
+
+// NewRequest creates new POST request delivering the event. The request
+// carries the same headers GitHub sends along with the deliveries.
+func NewRequest(url, secret, event string, payload interface{}) (*http.Request, error) {
+	if event == "" {
+		return nil, errors.New("webhooktest: empty event name")
+	}
+	body, err := Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GitHub-Hookshot/webhooktest")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", DeliveryID())
+	req.Header.Set("X-Hub-Signature", Signature(secret, body))
+	req.Header.Set("X-Hub-Signature-256", Signature256(secret, body))
+	return req, nil
+}
+
+// Client delivers signed events to a webhook listening on the URL.
+type Client struct {
+	URL    string // webhook's URL
+	Secret string // secret used for signing payloads
+
+	// Header specifies optional headers, which are added to each request.
+	// They overwrite the headers set by NewRequest.
+	Header http.Header
+
+	// Client specifies an optional HTTP client for sending requests.
+	// If nil, http.DefaultClient is used instead.
+	Client *http.Client
+}
+
+// NewClient creates new client, which delivers events to the given URL.
+func NewClient(url, secret string) *Client {
+	return &Client{
+		URL:    url,
+		Secret: secret,
+	}
+}
+
+// Deliver sends the payload as the given event type. See Marshal for
+// the supported payload types.
+func (c *Client) Deliver(event string, payload interface{}) (*http.Response, error) {
+	req, err := NewRequest(c.URL, c.Secret, event, payload)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	return c.client().Do(req)
+}
+
+// DeliverEvent sends the event payload, the event type is read from its
+// EventName method.
+func (c *Client) DeliverEvent(e webhook.Event) (*http.Response, error) {
+	return c.Deliver(e.EventName(), e)
+}
+
+// DeliverFile sends the JSON payload read from the file. The event type
+// is read from the file name, see EventName for details.
+func (c *Client) DeliverFile(file string) (*http.Response, error) {
+	body, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return c.Deliver(EventName(file), body)
+}
+
+func (c *Client) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
+
+// Server is a test HTTP server, which delivers events to the handler.
+type Server struct {
+	*httptest.Server
+	client *Client
+}
+
+// NewServer starts new test server for the handler. The secret is used for
+// signing the delivered events. The caller should call Close when finished,
+// to shut it down.
+func NewServer(secret string, handler http.Handler) *Server {
+	ts := httptest.NewServer(handler)
+	return &Server{
+		Server: ts,
+		client: NewClient(ts.URL, secret),
+	}
+}
+
+// Deliver sends the payload as the given event type to the server.
+func (s *Server) Deliver(event string, payload interface{}) (*http.Response, error) {
+	return s.client.Deliver(event, payload)
+}
+
+// DeliverEvent sends the event payload to the server.
+func (s *Server) DeliverEvent(e webhook.Event) (*http.Response, error) {
+	return s.client.DeliverEvent(e)
+}
+
+// DeliverFile sends the JSON payload read from the file to the server.
+func (s *Server) DeliverFile(file string) (*http.Response, error) {
+	return s.client.DeliverFile(file)
+}
@@ -0,0 +1,116 @@
+package webhooktest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/rjeczalik/gh/webhook"
+)
+
+const secret = "dupa.8"
+
+func fixtures(t *testing.T) []string {
+	files, err := filepath.Glob(filepath.Join("..", "testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no fixtures found")
+	}
+	return files
+}
+
+func TestEventName(t *testing.T) {
+	cases := map[string]string{
+		"push.json":                               "push",
+		"testdata/pull_request-edited.json":       "pull_request",
+		"release-2015-03-19 at 09.36.45.882.json": "release",
+		"pull_request_review_comment.json":        "pull_request_review_comment",
+	}
+	for file, event := range cases {
+		if name := EventName(file); name != event {
+			t.Errorf("want EventName(%q)=%s; got %s", file, event, name)
+		}
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	body := []byte(`{"zen":"Keep it logically awesome."}`)
+	req, err := NewRequest("http://localhost", secret, "ping", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"Content-Type":        "application/json",
+		"X-GitHub-Event":      "ping",
+		"X-Hub-Signature":     Signature(secret, body),
+		"X-Hub-Signature-256": Signature256(secret, body),
+	}
+	for k, v := range want {
+		if got := req.Header.Get(k); got != v {
+			t.Errorf("want %s=%q; got %q", k, v, got)
+		}
+	}
+	guid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if id := req.Header.Get("X-GitHub-Delivery"); !guid.MatchString(id) {
+		t.Errorf("want X-GitHub-Delivery to be a GUID; got %q", id)
+	}
+	if _, err := NewRequest("http://localhost", secret, "", body); err == nil {
+		t.Error("want NewRequest to fail on empty event name")
+	}
+}
+
+func TestServerDetailHandler(t *testing.T) {
+	h := NewDetailHandler()
+	ts := NewServer(secret, webhook.New(secret, h))
+	defer ts.Close()
+	files := fixtures(t)
+	count := make(map[string]int)
+	for _, file := range files {
+		resp, err := ts.DeliverFile(file)
+		AssertStatus(t, resp, err, http.StatusOK)
+		count[EventName(file)]++
+	}
+	for event, n := range count {
+		AssertRecorded(t, h.Recorder, event, n)
+	}
+	AssertRecorded(t, h.Recorder, "", len(files))
+	for _, rec := range h.Recorder.Records() {
+		e, ok := rec.Payload.(webhook.Event)
+		if !ok || e.EventName() != rec.Event {
+			t.Errorf("want %T to be %s event", rec.Payload, rec.Event)
+		}
+	}
+}
+
+func TestServerBlanketHandler(t *testing.T) {
+	h := NewBlanketHandler()
+	ts := NewServer(secret, webhook.New(secret, h))
+	defer ts.Close()
+	e := &webhook.PushEvent{Ref: "refs/heads/master"}
+	resp, err := ts.DeliverEvent(e)
+	AssertStatus(t, resp, err, http.StatusOK)
+	AssertRecorded(t, h.Recorder, "push", 1)
+	if got := h.Recorder.Records()[0].Payload.(*webhook.PushEvent); got.Ref != e.Ref {
+		t.Errorf("want Ref=%s; got %s", e.Ref, got.Ref)
+	}
+	h.Recorder.Reset()
+	AssertRecorded(t, h.Recorder, "push", 0)
+}
+
+func TestClientBadSignature(t *testing.T) {
+	h := NewBlanketHandler()
+	ts := httptest.NewServer(webhook.New(secret, h))
+	defer ts.Close()
+	c := NewClient(ts.URL, "invalid")
+	resp, err := c.Deliver("ping", `{"zen":"Design for failure."}`)
+	AssertBody(t, resp, err, "invalid signature")
+	c.Secret = secret
+	c.Header = http.Header{"X-Hub-Signature": {"md5=123"}}
+	resp, err = c.Deliver("ping", `{"zen":"Design for failure."}`)
+	AssertStatus(t, resp, err, http.StatusBadRequest)
+	AssertRecorded(t, h.Recorder, "", 0)
+}